@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+
+	"sloteriaa/struct/forgeron"
+)
+
+// EnterAlchimie: atelier où l'on brasse des potions à partir de matériaux
+func EnterAlchimie(gs *GameState) {
+	for {
+		recs := []forgeron.RecetteAlchimie{}
+		for _, r := range forgeron.RecettesAlchimie() {
+			if recetteConnue(gs, r.Resultat, r.Tier) {
+				recs = append(recs, r)
+			}
+		}
+		header := fmt.Sprintf("Atelier d'alchimie — Or %d\nMatériaux: %s", gs.Joueur.Argent, formatMaterials(forgeron.Cout(gs.Mats)))
+		opts := make([]string, 0, len(recs)+1)
+		for _, r := range recs {
+			opts = append(opts, fmt.Sprintf("%s x%d — Coût: %d or | Mat: %s (vous en avez %d)",
+				r.NomAffiche, r.Quantite, r.Cout[forgeron.Or], formatMaterials(r.Cout), compterObjet(&gs.Joueur, r.Resultat)))
+		}
+		opts = append(opts, "Quitter l'atelier")
+		idx, cancelled := selectWithArrows(header, opts)
+		if cancelled || idx == len(recs) {
+			return
+		}
+		brasser(gs, recs[idx])
+	}
+}
+
+// brasser fabrique une recette d'alchimie; le joueur fait d'abord de la place pour tout le lot
+func brasser(gs *GameState, r forgeron.RecetteAlchimie) {
+	if !ressourcesSuffisantes(gs, r.Cout) {
+		return
+	}
+	if !faireDeLaPlacePour(gs, fmt.Sprintf("%d x %s", r.Quantite, r.NomAffiche), PoidsObjet(r.Resultat)*r.Quantite) {
+		attendreEntree()
+		return
+	}
+	payerCout(gs, srcAlchimie, r.NomAffiche, r.Cout)
+	// Le brassage prend le temps d'une fabrication, sans faire progresser la forge
+	avancerTemps(gs, heuresParFabrication)
+	for i := 0; i < r.Quantite; i++ {
+		acquerirObjet(gs, r.Resultat)
+	}
+	fmt.Printf("⚗️ Vous brassez %d x %s.\n", r.Quantite, r.NomAffiche)
+	signalerQuete(gs, objBrasser, r.Resultat, r.Quantite)
+	attendreEntree()
+}
+
+// potionBrassable indique si un objet est le résultat d'une recette d'alchimie
+func potionBrassable(nom string) bool {
+	for _, r := range forgeron.RecettesAlchimie() {
+		if r.Resultat == nom {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	"sloteriaa/struct/forgeron"
+	"sloteriaa/struct/objet"
+)
+
+// Consommable qui évite la perte de niveau lors d'un échec à haut niveau
+const parcheminProtection = "parchemin protection"
+
+// Niveau à partir duquel un échec fait perdre un niveau
+const niveauRisqueRetrogradation = 7
+
+// coutAmelioration: matériaux de la recette multipliés selon le niveau visé,
+// plus de l'or et de l'essence magique pour les niveaux élevés
+func coutAmelioration(cle string, cible int) forgeron.Cout {
+	cout := forgeron.Cout{}
+	recette, ok := coutRecette(cle)
+	if !ok {
+		recette = forgeron.Cout{forgeron.Fer: 1, forgeron.Or: 100}
+	}
+	mult := (cible + 1) / 2
+	for m, q := range recette {
+		if m == forgeron.Or {
+			cout[m] = q / 2 * cible
+			continue
+		}
+		cout[m] = q * mult
+	}
+	if cible >= niveauRisqueRetrogradation {
+		cout[forgeron.EssenceMagique] += cible - niveauRisqueRetrogradation + 1
+	}
+	return cout
+}
+
+// Chance d'échec (%) pour atteindre un niveau donné
+func chanceEchecAmelioration(cible int) int {
+	if cible <= 3 {
+		return 0
+	}
+	return 15 + 10*(cible-4)
+}
+
+// forgeAmeliorer: amélioration +1 à +10 des armes et armures
+func forgeAmeliorer(gs *GameState) {
+	for {
+		nom, ok := choisirEquipement(gs, "Améliorer quel objet ?")
+		if !ok {
+			return
+		}
+		ameliorerObjet(gs, nom)
+	}
+}
+
+func ameliorerObjet(gs *GameState, nom string) {
+	for {
+		ex, _ := exemplaireDe(&gs.Joueur, nom)
+		if ex.Cle == "" {
+			cle, armure, _ := cleObjet(nom)
+			ex = objet.Exemplaire{Cle: cle, Armure: armure}
+		}
+		stat := fmt.Sprintf("ATK %d", ex.StatsArme().EffetAttaque)
+		if ex.Armure {
+			stat = fmt.Sprintf("DEF %d", ex.StatsArmure().EffetDefense)
+		}
+		header := fmt.Sprintf("Amélioration — %s (%s)\nOr %d | Parchemins de protection: %d",
+			libelleExemplaire(&gs.Joueur, nom), stat, gs.Joueur.Argent, compterObjet(&gs.Joueur, parcheminProtection))
+		if ex.Niveau >= objet.NiveauMaxAmelioration {
+			fmt.Println(header)
+			fmt.Println("Niveau maximal atteint.")
+			attendreEntree()
+			return
+		}
+		cible := ex.Niveau + 1
+		cout := coutAmelioration(ex.Cle, cible)
+		opt := fmt.Sprintf("Améliorer +%d → +%d — %d or | Mat: %s (échec %d%%)", ex.Niveau, cible, cout[forgeron.Or], formatMaterials(cout), chanceEchecAmelioration(cible))
+		idx, cancelled := selectWithArrows(header, []string{opt, "Retour"})
+		if cancelled || idx == 1 {
+			return
+		}
+		// Proposer la protection si un échec ferait perdre un niveau
+		proteger := false
+		if cible >= niveauRisqueRetrogradation && compterObjet(&gs.Joueur, parcheminProtection) > 0 {
+			i, c := selectWithArrows("Utiliser un parchemin de protection ? (évite la perte de niveau en cas d'échec)", []string{"Oui", "Non"})
+			proteger = !c && i == 0
+		}
+		if !ressourcesSuffisantes(gs, cout) {
+			continue
+		}
+		payerCout(gs, srcAmelioration, nom, cout)
+		if proteger {
+			retirerObjetParNom(&gs.Joueur, parcheminProtection)
+		}
+		if rand.Intn(100) < chanceEchecAmelioration(cible) {
+			fmt.Println("💥 L'amélioration échoue ! Les matériaux sont perdus.")
+			if cible >= niveauRisqueRetrogradation && !proteger {
+				nom = changerNiveau(gs, nom, ex.Niveau-1)
+				fmt.Printf("L'objet perd un niveau : %s\n", libelleExemplaire(&gs.Joueur, nom))
+			} else if proteger {
+				fmt.Println("📜 Le parchemin de protection se consume et préserve l'objet.")
+			}
+			attendreEntree()
+			continue
+		}
+		nom = changerNiveau(gs, nom, cible)
+		fmt.Printf("🔨 Amélioration réussie : %s\n", libelleExemplaire(&gs.Joueur, nom))
+		attendreEntree()
+	}
+}
+
+// changerNiveau fixe le niveau d'amélioration d'un objet et le renomme partout; retourne le nouveau nom
+func changerNiveau(gs *GameState, nom string, niveau int) string {
+	unique, ok := assurerExemplaire(gs, nom)
+	if !ok {
+		return nom
+	}
+	ex := gs.Joueur.Exemplaires[unique]
+	ex.Niveau = niveau
+	gs.Joueur.Exemplaires[unique] = ex
+	nouveau := ex.Nom()
+	renommerObjet(&gs.Joueur, unique, nouveau)
+	return nouveau
+}
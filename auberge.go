@@ -0,0 +1,108 @@
+package main
+
+import "fmt"
+
+// Prestation de l'auberge: prix de base, majoré avec le niveau du joueur, et durée en heures de jeu
+type prestation struct {
+	Nom    string
+	Detail string
+	Prix   int
+	Heures int
+	Effet  func(gs *GameState) bool // false: rien à faire, la prestation n'est pas facturée
+}
+
+// Or supplémentaire par niveau du joueur sur chaque prestation
+const majorationParNiveau = 2
+
+func prestationsAuberge() []prestation {
+	return []prestation{
+		{"Sieste", "+30% des PV", 10, 2, func(gs *GameState) bool {
+			return soigner(gs, gs.Joueur.PVMax*30/100)
+		}},
+		{"Nuit complète", "PV au maximum, statuts guéris", 35, 8, func(gs *GameState) bool {
+			soin := soigner(gs, gs.Joueur.PVMax)
+			return guerirStatuts(gs) || soin
+		}},
+		{"Bain aux herbes", "guérit poison, brûlure et saignement", 15, 1, guerirStatuts},
+		{"Repas copieux", "prolonge les effets de potion actifs", 20, 1, prolongerBuffs},
+	}
+}
+
+// EnterAuberge propose le repos payant: soins, guérison des statuts et prolongation des buffs
+func EnterAuberge(gs *GameState) {
+	for {
+		j := &gs.Joueur
+		header := fmt.Sprintf("Auberge du Sanglier — %s — Or %d — PV %d/%d", libelleHeure(gs), j.Argent, j.PVActuels, j.PVMax)
+		if statuts := statutsJoueur(j); statuts != "" {
+			header += " — " + statuts
+		}
+		prestations := prestationsAuberge()
+		opts := make([]string, 0, len(prestations)+1)
+		for _, p := range prestations {
+			opts = append(opts, fmt.Sprintf("%s — %s (%d or, %d h)", p.Nom, p.Detail, prixPrestation(gs, p), p.Heures))
+		}
+		opts = append(opts, "Quitter l'auberge")
+		idx, cancelled := selectWithArrows(header, opts)
+		if cancelled || idx == len(prestations) {
+			return
+		}
+		p := prestations[idx]
+		prix := prixPrestation(gs, p)
+		if j.Argent < prix {
+			fmt.Println("Pas assez d'or.")
+			attendreEntree()
+			continue
+		}
+		if !p.Effet(gs) {
+			fmt.Println("Vous n'en avez pas besoin pour le moment.")
+			attendreEntree()
+			continue
+		}
+		mouvementOr(gs, srcAuberge, p.Nom, -prix)
+		avancerTemps(gs, p.Heures)
+		fmt.Printf("🛏️ %s terminée. PV %d/%d — %s\n", p.Nom, j.PVActuels, j.PVMax, libelleHeure(gs))
+		attendreEntree()
+	}
+}
+
+func prixPrestation(gs *GameState, p prestation) int {
+	return p.Prix + majorationParNiveau*gs.Level
+}
+
+// soigner rend des PV sans dépasser le maximum; false si le joueur est déjà en pleine santé
+func soigner(gs *GameState, pv int) bool {
+	j := &gs.Joueur
+	if j.PVActuels >= j.PVMax {
+		return false
+	}
+	j.PVActuels = min(j.PVMax, j.PVActuels+pv)
+	return true
+}
+
+// guerirStatuts retire poison, brûlure et saignement
+func guerirStatuts(gs *GameState) bool {
+	if len(gs.Joueur.Statuts) == 0 {
+		return false
+	}
+	gs.Joueur.Statuts = nil
+	return true
+}
+
+// prolongerBuffs remet à leur durée initiale les effets de potion encore actifs
+func prolongerBuffs(gs *GameState) bool {
+	j := &gs.Joueur
+	prolonge := false
+	if j.BuffCombats > 0 && j.BuffCombats < 3 {
+		j.BuffCombats = 3
+		prolonge = true
+	}
+	if j.ResistanceFeu > 0 && j.ResistanceFeu < 3 {
+		j.ResistanceFeu = 3
+		prolonge = true
+	}
+	if j.Purification > 0 && j.Purification < 2 {
+		j.Purification = 2
+		prolonge = true
+	}
+	return prolonge
+}
@@ -0,0 +1,301 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"sloteriaa/internal/personnage"
+	"sloteriaa/struct/forgeron"
+)
+
+//go:embed data/carte.json
+var donneesCarte []byte
+
+// Carte du monde: lieux reliés par des chemins, décrits dans data/carte.json
+type Carte struct {
+	Depart string `json:"depart"`
+	Lieux  []Lieu `json:"lieux"`
+}
+
+type Lieu struct {
+	Cle         string        `json:"cle"`
+	Nom         string        `json:"nom"`
+	Description string        `json:"description"`
+	Niveau      int           `json:"niveau"` // niveau conseillé
+	Services    []string      `json:"services"`
+	Monstres    []MonstreLieu `json:"monstres"`
+	Recolte     []RecolteLieu `json:"recolte"`
+	Chemins     []Chemin      `json:"chemins"`
+}
+
+// Monstre de la table d'un lieu; Poids règle sa fréquence
+type MonstreLieu struct {
+	Nom     string `json:"nom"`
+	Tier    int    `json:"tier"`
+	PV      int    `json:"pv"`
+	Attaque int    `json:"attaque"`
+	Defense int    `json:"defense"`
+	Type    string `json:"type"`
+	Poids   int    `json:"poids"`
+}
+
+type RecolteLieu struct {
+	Materiau string `json:"materiau"`
+	Quantite int    `json:"quantite"`
+	Chance   int    `json:"chance"` // en %
+}
+
+// Chemin vers un autre lieu: durée du trajet et chance (en %) de rencontre en route
+type Chemin struct {
+	Vers      string `json:"vers"`
+	Heures    int    `json:"heures"`
+	Rencontre int    `json:"rencontre"`
+}
+
+const (
+	heuresRecolte      = 3  // heures passées à récolter sur place
+	bonusRencontreNuit = 10 // % de rencontre en plus sur les chemins la nuit
+)
+
+var (
+	carte        Carte
+	carteChargee sync.Once
+)
+
+// carteDuMonde charge et vérifie la carte embarquée au premier accès
+func carteDuMonde() Carte {
+	carteChargee.Do(func() {
+		if err := json.Unmarshal(donneesCarte, &carte); err != nil {
+			panic(fmt.Sprintf("carte du monde invalide: %s", err))
+		}
+		if err := verifierCarte(carte); err != nil {
+			panic(fmt.Sprintf("carte du monde invalide: %s", err))
+		}
+	})
+	return carte
+}
+
+// verifierCarte contrôle que les chemins, services et matériaux du fichier existent
+func verifierCarte(c Carte) error {
+	cles := map[string]bool{}
+	for _, l := range c.Lieux {
+		cles[l.Cle] = true
+	}
+	if !cles[c.Depart] {
+		return fmt.Errorf("lieu de départ inconnu: %s", c.Depart)
+	}
+	for _, l := range c.Lieux {
+		for _, ch := range l.Chemins {
+			if !cles[ch.Vers] {
+				return fmt.Errorf("%s: chemin vers un lieu inconnu: %s", l.Cle, ch.Vers)
+			}
+		}
+		for _, s := range l.Services {
+			if _, ok := servicesLieu()[s]; !ok {
+				return fmt.Errorf("%s: service inconnu: %s", l.Cle, s)
+			}
+		}
+		for _, r := range l.Recolte {
+			if _, ok := forgeron.MateriauDepuisNom(r.Materiau); !ok {
+				return fmt.Errorf("%s: matériau inconnu: %s", l.Cle, r.Materiau)
+			}
+		}
+	}
+	return nil
+}
+
+// Service proposé par un lieu de la carte
+type serviceLieu struct {
+	Libelle string
+	Action  func(gs *GameState)
+}
+
+// servicesLieu associe les clés de service de data/carte.json à leur écran
+func servicesLieu() map[string]serviceLieu {
+	return map[string]serviceLieu{
+		"forge":    {"Aller à la Forge", EnterForge},
+		"marche":   {"Aller au Marché", EnterShop},
+		"coffre":   {"Coffre de la ville", EnterCoffre},
+		"alchimie": {"Atelier d'alchimie", EnterAlchimie},
+		"auberge":  {"Auberge du Sanglier", EnterAuberge},
+		"donjon":   {"Entrer dans le Donjon", EnterDungeon},
+		"explorer": {"Explorer les environs", explorerLieu},
+		"recolter": {"Récolter des matériaux", recolterLieu},
+		"parler":   {"Parler aux habitants", EnterParler},
+	}
+}
+
+func lieuParCle(cle string) (Lieu, bool) {
+	for _, l := range carteDuMonde().Lieux {
+		if l.Cle == cle {
+			return l, true
+		}
+	}
+	return Lieu{}, false
+}
+
+// lieuActuel retourne le lieu où se trouve le joueur (le départ pour une ancienne sauvegarde)
+func lieuActuel(gs *GameState) Lieu {
+	if l, ok := lieuParCle(gs.Lieu); ok {
+		return l
+	}
+	l, _ := lieuParCle(carteDuMonde().Depart)
+	return l
+}
+
+// retourVille ramène le joueur au lieu de départ (après une défaite)
+func retourVille(gs *GameState) {
+	gs.Lieu = carteDuMonde().Depart
+}
+
+// worldLoop propose les services du lieu actuel, le voyage et les menus du personnage
+func worldLoop(gs *GameState) {
+	for {
+		l := lieuActuel(gs)
+		header := fmt.Sprintf("%s — %s — Niveau %d (XP %d) — Or %d\n%s", l.Nom, libelleHeure(gs), gs.Level, gs.XP, gs.Joueur.Argent, l.Description)
+		opts := []string{}
+		actions := []func(gs *GameState){}
+		services := servicesLieu()
+		for _, s := range l.Services {
+			opts = append(opts, services[s].Libelle)
+			actions = append(actions, services[s].Action)
+		}
+		opts = append(opts, "Voyager", "Inventaire", "Stats du personnage", "Journal de quêtes", "Journal des comptes", "Sauvegarder", "Quitter le jeu")
+		choice, cancelled := selectWithArrows(header, opts)
+		if cancelled || choice == len(opts)-1 {
+			showCursor() // Réaffiche le curseur avant de quitter
+			fmt.Println("À bientôt !")
+			return
+		}
+		if choice < len(actions) {
+			actions[choice](gs)
+			continue
+		}
+		switch choice - len(actions) {
+		case 0:
+			voyager(gs)
+		case 1:
+			afficherInventaireInteractif(&gs.Joueur)
+		case 2:
+			clearScreen()
+			personnage.AfficherInfos(gs.Joueur)
+			afficherReputation(gs)
+			attendreEntree()
+			clearScreen()
+		case 3:
+			EnterQuetes(gs)
+		case 4:
+			EnterJournal(gs)
+		case 5:
+			if err := SaveGame(gs); err != nil {
+				fmt.Printf("Erreur de sauvegarde: %s\n", err)
+			} else {
+				fmt.Println("Sauvegarde effectuée.")
+			}
+			attendreEntree()
+		}
+	}
+}
+
+// voyager déplace le joueur vers un lieu voisin: le trajet prend du temps et peut tourner au combat
+func voyager(gs *GameState) {
+	depart := lieuActuel(gs)
+	opts := make([]string, 0, len(depart.Chemins)+1)
+	for _, ch := range depart.Chemins {
+		dest, _ := lieuParCle(ch.Vers)
+		label := fmt.Sprintf("%s — %d h de marche, rencontre %d%%", dest.Nom, ch.Heures, chanceRencontre(gs, ch))
+		if dest.Niveau > gs.Level {
+			label += fmt.Sprintf(" (niveau conseillé %d)", dest.Niveau)
+		}
+		opts = append(opts, label)
+	}
+	opts = append(opts, "Rester ici")
+	idx, cancelled := selectWithArrows(fmt.Sprintf("Voyager depuis %s — %s", depart.Nom, libelleHeure(gs)), opts)
+	if cancelled || idx == len(depart.Chemins) {
+		return
+	}
+	ch := depart.Chemins[idx]
+	dest, _ := lieuParCle(ch.Vers)
+	fmt.Printf("🧭 Vous prenez la route vers %s...\n", dest.Nom)
+	rencontre := rand.Intn(100) < chanceRencontre(gs, ch)
+	avancerTemps(gs, ch.Heures)
+	if rencontre {
+		// Les monstres du chemin viennent du lieu le plus sauvage des deux
+		table := dest.Monstres
+		if len(table) == 0 {
+			table = depart.Monstres
+		}
+		if len(table) > 0 {
+			fmt.Println("⚠️ Une rencontre en chemin !")
+			attendreEntree()
+			if mon, tier := monstreDeTable(table); combattre(gs, tier, mon) == defaite {
+				return
+			}
+		}
+	}
+	gs.Lieu = dest.Cle
+	fmt.Printf("📍 Vous arrivez: %s (%s)\n", dest.Nom, libelleHeure(gs))
+	attendreEntree()
+}
+
+func chanceRencontre(gs *GameState, ch Chemin) int {
+	if estNuit(gs) {
+		return ch.Rencontre + bonusRencontreNuit
+	}
+	return ch.Rencontre
+}
+
+// monstreDeTable tire un monstre de la table selon les poids et retourne son tier
+func monstreDeTable(table []MonstreLieu) (Monster, int) {
+	total := 0
+	for _, m := range table {
+		total += m.Poids
+	}
+	tirage := rand.Intn(max(1, total))
+	choisi := table[0]
+	for _, m := range table {
+		if tirage < m.Poids {
+			choisi = m
+			break
+		}
+		tirage -= m.Poids
+	}
+	return Monster{Nom: choisi.Nom, PV: choisi.PV, PVMax: choisi.PV, Attaque: choisi.Attaque, Defense: choisi.Defense, Type: choisi.Type}, choisi.Tier
+}
+
+// explorerLieu affronte un monstre de la table du lieu, comme une salle du donjon
+func explorerLieu(gs *GameState) {
+	l := lieuActuel(gs)
+	if len(l.Monstres) == 0 {
+		fmt.Println("Rien à explorer ici.")
+		attendreEntree()
+		return
+	}
+	fmt.Printf("🔎 Vous explorez %s...\n", l.Nom)
+	mon, tier := monstreDeTable(l.Monstres)
+	combattre(gs, tier, mon)
+	finExpedition(gs)
+}
+
+// recolterLieu ramasse les matériaux du lieu selon leurs chances
+func recolterLieu(gs *GameState) {
+	l := lieuActuel(gs)
+	fmt.Printf("⛏️ Vous récoltez dans %s pendant %d h...\n", l.Nom, heuresRecolte)
+	trouve := false
+	for _, r := range l.Recolte {
+		if rand.Intn(100) < r.Chance {
+			m, _ := forgeron.MateriauDepuisNom(r.Materiau)
+			mouvementMateriau(gs, srcRecolte, l.Nom, m, r.Quantite)
+			fmt.Printf("  📦 %s x%d\n", m, r.Quantite)
+			trouve = true
+		}
+	}
+	if !trouve {
+		fmt.Println("  Rien d'utile cette fois.")
+	}
+	avancerTemps(gs, heuresRecolte)
+	attendreEntree()
+}
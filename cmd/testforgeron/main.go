@@ -11,6 +11,6 @@ func main() {
 		forgeron.Cuir:           4,
 		forgeron.EssenceMagique: 2,
 	}
-	// Mode interactif avec flèches (sans Entrée)
-	forgeron.RunForgeInteractive(inv)
+	// Mode interactif avec flèches (sans Entrée), sur un atelier en mémoire
+	forgeron.RunForgeInteractive(&forgeron.AtelierSimple{Inv: inv, Argent: 1000, Capacite: 50})
 }
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+
+	"sloteriaa/struct/forgeron"
+)
+
+// Coffre de la ville: stockage d'objets et d'or hors de l'inventaire
+type Coffre struct {
+	Objets []string
+	Or     int
+	Niveau int // niveau d'agrandissement (0 = coffre de base)
+}
+
+const niveauMaxCoffre = 5
+
+// Capacité du coffre en poids selon son niveau
+func capaciteCoffre(niveau int) int {
+	return 100 + 50*niveau
+}
+
+// Coût pour passer du niveau actuel au suivant
+func coutAgrandissementCoffre(niveau int) forgeron.Cout {
+	n := niveau + 1
+	return forgeron.Cout{forgeron.Or: 250 * n, forgeron.Bois: 4 * n, forgeron.Fer: 2 * n}
+}
+
+func poidsCoffre(c *Coffre) int {
+	total := 0
+	for _, o := range c.Objets {
+		total += PoidsObjet(o)
+	}
+	return total
+}
+
+func EnterCoffre(gs *GameState) {
+	for {
+		c := &gs.Coffre
+		header := fmt.Sprintf("Coffre de la ville — Or déposé %d | Poids %d/%d | Or sur vous %d",
+			c.Or, poidsCoffre(c), capaciteCoffre(c.Niveau), gs.Joueur.Argent)
+		agrandir := "Agrandir le coffre (niveau max atteint)"
+		if c.Niveau < niveauMaxCoffre {
+			cout := coutAgrandissementCoffre(c.Niveau)
+			agrandir = fmt.Sprintf("Agrandir le coffre (niv. %d → %d) — %d or | Mat: %s", c.Niveau, c.Niveau+1, cout[forgeron.Or], formatMaterials(cout))
+		}
+		idx, cancelled := selectWithArrows(header, []string{"Déposer un objet", "Retirer un objet", "Déposer de l'or", "Retirer de l'or", agrandir, "Fermer le coffre"})
+		if cancelled || idx == 5 {
+			return
+		}
+		switch idx {
+		case 0:
+			deposerObjetCoffre(gs)
+		case 1:
+			retirerObjetCoffre(gs)
+		case 2:
+			if q, ok := choisirMontantOr("Déposer combien d'or ?", gs.Joueur.Argent); ok {
+				mouvementOr(gs, srcCoffre, "Dépôt", -q)
+				c.Or += q
+			}
+		case 3:
+			if q, ok := choisirMontantOr("Retirer combien d'or ?", c.Or); ok {
+				c.Or -= q
+				mouvementOr(gs, srcCoffre, "Retrait", q)
+			}
+		case 4:
+			if c.Niveau >= niveauMaxCoffre {
+				fmt.Println("Le coffre est déjà à sa taille maximale.")
+				attendreEntree()
+				continue
+			}
+			cout := coutAgrandissementCoffre(c.Niveau)
+			if !ressourcesSuffisantes(gs, cout) {
+				continue
+			}
+			payerCout(gs, srcCoffre, "Agrandissement", cout)
+			c.Niveau++
+			fmt.Printf("📦 Coffre agrandi ! Capacité: %d\n", capaciteCoffre(c.Niveau))
+			attendreEntree()
+		}
+	}
+}
+
+// deposerObjetCoffre propose les objets non équipés et les range dans le coffre.
+// Retourne true si un objet a été déposé.
+func deposerObjetCoffre(gs *GameState) bool {
+	j := &gs.Joueur
+	noms, counts := compterItems(j)
+	deposables := []string{}
+	opts := []string{}
+	for _, item := range noms {
+		if estArmeEquipee(j, item) || estArmureEquipee(j, item) {
+			continue
+		}
+		deposables = append(deposables, item)
+		opts = append(opts, fmt.Sprintf("%s x%d (poids %d)", item, counts[item], PoidsObjet(item)))
+	}
+	if len(deposables) == 0 {
+		fmt.Println("Aucun objet non équipé à déposer.")
+		attendreEntree()
+		return false
+	}
+	idx, cancelled := selectWithArrows("Déposer quel objet ?", opts)
+	if cancelled {
+		return false
+	}
+	nom := deposables[idx]
+	if poidsCoffre(&gs.Coffre)+PoidsObjet(nom) > capaciteCoffre(gs.Coffre.Niveau) {
+		fmt.Println("❌ Le coffre est plein. Agrandissez-le pour stocker davantage.")
+		attendreEntree()
+		return false
+	}
+	retirerObjetParNom(j, nom)
+	gs.Coffre.Objets = append(gs.Coffre.Objets, nom)
+	fmt.Printf("📦 %s déposé dans le coffre.\n", nom)
+	return true
+}
+
+func retirerObjetCoffre(gs *GameState) {
+	c := &gs.Coffre
+	if len(c.Objets) == 0 {
+		fmt.Println("Le coffre est vide.")
+		attendreEntree()
+		return
+	}
+	noms := []string{}
+	counts := map[string]int{}
+	for _, o := range c.Objets {
+		if _, ok := counts[o]; !ok {
+			noms = append(noms, o)
+		}
+		counts[o]++
+	}
+	opts := make([]string, len(noms))
+	for i, n := range noms {
+		opts[i] = fmt.Sprintf("%s x%d (poids %d)", n, counts[n], PoidsObjet(n))
+	}
+	idx, cancelled := selectWithArrows("Retirer quel objet ?", opts)
+	if cancelled {
+		return
+	}
+	nom := noms[idx]
+	if !acquerirObjet(gs, nom) {
+		return
+	}
+	for i, o := range c.Objets {
+		if o == nom {
+			c.Objets = append(c.Objets[:i], c.Objets[i+1:]...)
+			break
+		}
+	}
+	fmt.Printf("Vous reprenez %s.\n", nom)
+}
+
+// choisirMontantOr demande un montant d'or plafonné au disponible
+func choisirMontantOr(header string, disponible int) (int, bool) {
+	if disponible <= 0 {
+		fmt.Println("Pas d'or disponible.")
+		attendreEntree()
+		return 0, false
+	}
+	q, cancelled := saisirQuantite(fmt.Sprintf("%s (disponible: %d or)", header, disponible), disponible)
+	return q, !cancelled
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"fmt"
+
+	"sloteriaa/struct/forgeron"
+	"sloteriaa/struct/objet"
+)
+
+// Commande passée au forgeron, payée d'avance et livrée après quelques expéditions au donjon
+type Commande struct {
+	Cle      string
+	Armure   bool
+	Variante string
+	Quantite int
+	Restant  int // expéditions restantes avant que la commande soit prête
+}
+
+// Expéditions nécessaires par objet commandé
+const expeditionsParObjet = 1
+
+// nomCommande retourne le nom de base de l'objet commandé
+func nomCommande(c Commande) string {
+	if c.Armure {
+		return objet.CreerArmure(c.Cle).Nom
+	}
+	return objet.CreerArme(c.Cle).Nom
+}
+
+func varianteParNom(nom string) forgeron.VarianteRecette {
+	for _, v := range forgeron.VariantesRecette() {
+		if v.Nom == nom {
+			return v
+		}
+	}
+	return forgeron.VariantesRecette()[0]
+}
+
+// multiplierCout retourne le coût total de n fabrications
+func multiplierCout(c forgeron.Cout, n int) forgeron.Cout {
+	total := forgeron.Cout{}
+	for m, q := range c {
+		total[m] = q * n
+	}
+	return total
+}
+
+// maxAbordable calcule combien d'exemplaires l'or, les matériaux et le poids libre permettent
+func maxAbordable(gs *GameState, c forgeron.Cout, poids int) int {
+	n := -1
+	for m, q := range c {
+		if q <= 0 {
+			continue
+		}
+		dispo := gs.Mats[m]
+		if m == forgeron.Or {
+			dispo = gs.Joueur.Argent
+		}
+		if n < 0 || dispo/q < n {
+			n = dispo / q
+		}
+	}
+	if poids > 0 {
+		if place := (PoidsMaxInventaire - PoidsTotal(&gs.Joueur)) / poids; n < 0 || place < n {
+			n = place
+		}
+	}
+	if n < 0 {
+		n = 0
+	}
+	return n
+}
+
+// fabriquerRecette choisit la variante puis le mode de fabrication: unité, lot, maximum ou commande
+func fabriquerRecette(gs *GameState, cle string, armure bool, coutBase forgeron.Cout) {
+	v, cout, ok := choisirVariante(gs, coutBase)
+	if !ok {
+		return
+	}
+	c := Commande{Cle: cle, Armure: armure, Variante: v.Nom}
+	nom := nomCommande(c)
+	nMax := maxAbordable(gs, cout, PoidsObjet(nom))
+	opts := []string{
+		"Forger 1",
+		"Forger plusieurs…",
+		fmt.Sprintf("Forger le maximum abordable (%d)", nMax),
+		fmt.Sprintf("Commander au forgeron (prêt après %d expédition(s) par objet)", expeditionsParObjet),
+		"Annuler",
+	}
+	idx, cancelled := selectWithArrows(fmt.Sprintf("%s — Coût unitaire: %d or | Mat: %s", nom, cout[forgeron.Or], formatMaterials(cout)), opts)
+	if cancelled {
+		return
+	}
+	switch idx {
+	case 0:
+		fabriquerLot(gs, c, cout, 1)
+	case 1:
+		if nMax == 0 {
+			fmt.Println("Ressources ou place insuffisantes pour forger un seul exemplaire.")
+			attendreEntree()
+			return
+		}
+		n, cancelled := saisirQuantite(fmt.Sprintf("Forger %s — maximum abordable: %d", nom, nMax), nMax)
+		if cancelled {
+			return
+		}
+		fabriquerLot(gs, c, cout, n)
+	case 2:
+		if nMax == 0 {
+			fmt.Println("Ressources ou place insuffisantes pour forger un seul exemplaire.")
+			attendreEntree()
+			return
+		}
+		fabriquerLot(gs, c, cout, nMax)
+	case 3:
+		// La commande n'encombre pas l'inventaire avant d'être retirée
+		nCommande := maxAbordable(gs, cout, 0)
+		if nCommande == 0 {
+			fmt.Println("Ressources insuffisantes pour commander un seul exemplaire.")
+			attendreEntree()
+			return
+		}
+		n, cancelled := saisirQuantite(fmt.Sprintf("Commander %s — maximum abordable: %d", nom, nCommande), nCommande)
+		if cancelled {
+			return
+		}
+		// Payée d'avance; l'XP, le temps et la réputation viennent à la livraison
+		total := multiplierCout(cout, n)
+		if !ressourcesSuffisantes(gs, total) {
+			return
+		}
+		payerCout(gs, srcForge, fmt.Sprintf("Commande %d x %s", n, nom), total)
+		c.Quantite = n
+		c.Restant = n * expeditionsParObjet
+		gs.Commandes = append(gs.Commandes, c)
+		fmt.Printf("📋 Commande passée: %d x %s. À retirer à la forge après vos expéditions.\n", n, nom)
+		attendreEntree()
+	}
+}
+
+// fabriquerLot forge jusqu'à n exemplaires; chaque objet est payé une fois la place faite dans l'inventaire
+func fabriquerLot(gs *GameState, c Commande, cout forgeron.Cout, n int) {
+	if !ressourcesSuffisantes(gs, multiplierCout(cout, n)) {
+		return
+	}
+	forges := 0
+	for ; forges < n; forges++ {
+		if !faireDeLaPlace(gs, nomCommande(c)) {
+			break
+		}
+		nom, annonces := forgerObjet(gs, c, cout)
+		fmt.Printf("Forgé: %s — Ajouté à l'inventaire\n", libelleExemplaire(&gs.Joueur, nom))
+		for _, msg := range annonces {
+			fmt.Println(msg)
+		}
+	}
+	if forges > 0 {
+		apresForge(gs, c.Cle, forges)
+	}
+	attendreEntree()
+}
+
+// forgerObjet paie une fabrication puis produit l'objet.
+// Règle commune à la forge en menus et à l'atelier visuel; la place doit avoir été faite par l'appelant.
+// Retourne le nom obtenu et les annonces de progression.
+func forgerObjet(gs *GameState, c Commande, cout forgeron.Cout) (string, []string) {
+	payerCout(gs, srcForge, nomCommande(c), cout)
+	return produireObjet(gs, c)
+}
+
+// produireObjet fait progresser la forge et ajoute un objet déjà payé à l'inventaire, sans rien afficher
+// (forge directe ou livraison d'une commande)
+func produireObjet(gs *GameState, c Commande) (string, []string) {
+	annonces := progresserForge(gs, xpParFabrication)
+	nom := fabriquerEquipement(gs, c.Cle, c.Armure, varianteParNom(c.Variante))
+	if msg := rangerObjet(gs, nom); msg != "" {
+		annonces = append(annonces, msg)
+	}
+	return nom, annonces
+}
+
+// apresForge applique les suites de n fabrications d'une même recette: temps passé, réputation et quêtes
+func apresForge(gs *GameState, cle string, n int) {
+	avancerTemps(gs, heuresParFabrication*n)
+	modifierReputation(gs, factionForgerons, repParObjetForge*n)
+	signalerQuete(gs, objFabriquer, cle, n)
+}
+
+// avancerCommandes fait progresser la commande en tête de file après une expédition au donjon
+func avancerCommandes(gs *GameState) {
+	for i := range gs.Commandes {
+		c := &gs.Commandes[i]
+		if c.Restant <= 0 {
+			continue
+		}
+		c.Restant--
+		if c.Restant == 0 {
+			fmt.Printf("🔨 Le forgeron a terminé votre commande: %d x %s.\n", c.Quantite, nomCommande(*c))
+		}
+		return
+	}
+}
+
+// retirerCommandes livre les commandes prêtes, dans la limite de la place disponible.
+// Chaque objet livré compte comme une fabrication (XP, temps, réputation, quêtes).
+func retirerCommandes(gs *GameState) {
+	restantes := []Commande{}
+	livre := 0
+	plein := false
+	for _, c := range gs.Commandes {
+		if c.Restant > 0 || plein {
+			restantes = append(restantes, c)
+			continue
+		}
+		livres := 0
+		for c.Quantite > 0 {
+			if !faireDeLaPlace(gs, nomCommande(c)) {
+				plein = true
+				break
+			}
+			nom, annonces := produireObjet(gs, c)
+			fmt.Printf("📦 Commande retirée: %s\n", libelleExemplaire(&gs.Joueur, nom))
+			for _, msg := range annonces {
+				fmt.Println(msg)
+			}
+			c.Quantite--
+			livres++
+		}
+		if livres > 0 {
+			apresForge(gs, c.Cle, livres)
+			livre += livres
+		}
+		if c.Quantite > 0 {
+			restantes = append(restantes, c)
+		}
+	}
+	gs.Commandes = restantes
+	if livre == 0 && !plein {
+		fmt.Println("Aucune commande prête.")
+	}
+	attendreEntree()
+}
+
+// commandesLabel résume la file de commandes pour le menu de la forge
+func commandesLabel(gs *GameState) string {
+	pretes := 0
+	for _, c := range gs.Commandes {
+		if c.Restant == 0 {
+			pretes++
+		}
+	}
+	return fmt.Sprintf("Commandes (%d en cours, %d prête(s))", len(gs.Commandes)-pretes, pretes)
+}
+
+// EnterCommandes affiche la file de commandes du forgeron
+func EnterCommandes(gs *GameState) {
+	for {
+		header := "Commandes au forgeron"
+		if len(gs.Commandes) == 0 {
+			header += "\n(aucune commande en cours)"
+		}
+		for i, c := range gs.Commandes {
+			etat := "✅ prête"
+			if c.Restant > 0 {
+				etat = fmt.Sprintf("⏳ encore %d expédition(s)", c.Restant)
+			}
+			header += fmt.Sprintf("\n%d. %d x %s (%s) — %s", i+1, c.Quantite, nomCommande(c), c.Variante, etat)
+		}
+		idx, cancelled := selectWithArrows(header, []string{"Retirer les commandes prêtes", "Retour"})
+		if cancelled || idx == 1 {
+			return
+		}
+		retirerCommandes(gs)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"sloteriaa/struct/forgeron"
+	"sloteriaa/struct/objet"
+)
+
+// XP de forge gagnée à chaque fabrication
+const xpParFabrication = 10
+
+// XP nécessaire pour passer au niveau de forge suivant
+func xpForgeRequise(niveau int) int {
+	return 20 * (niveau + 1)
+}
+
+// progresserForge ajoute l'XP de forge et retourne les annonces de niveau à afficher
+func progresserForge(gs *GameState, xp int) []string {
+	j := &gs.Joueur
+	annonces := []string{}
+	if j.CompetenceForge >= objet.CompetenceForgeMax {
+		return annonces
+	}
+	j.XPForge += xp
+	for j.CompetenceForge < objet.CompetenceForgeMax && j.XPForge >= xpForgeRequise(j.CompetenceForge) {
+		j.XPForge -= xpForgeRequise(j.CompetenceForge)
+		j.CompetenceForge++
+		annonces = append(annonces, fmt.Sprintf("🔨 Compétence de forge niveau %d !", j.CompetenceForge))
+		for _, v := range forgeron.VariantesRecette() {
+			if v.NiveauRequis == j.CompetenceForge {
+				annonces = append(annonces, fmt.Sprintf("   Nouvelle variante de fabrication: %s", v.Nom))
+			}
+		}
+	}
+	return annonces
+}
+
+// choisirVariante propose les variantes débloquées pour un coût de recette.
+// Sans variante débloquée, la recette standard est retenue directement.
+func choisirVariante(gs *GameState, cout forgeron.Cout) (forgeron.VarianteRecette, forgeron.Cout, bool) {
+	dispo := []forgeron.VarianteRecette{}
+	for _, v := range forgeron.VariantesRecette() {
+		if gs.Joueur.CompetenceForge >= v.NiveauRequis {
+			dispo = append(dispo, v)
+		}
+	}
+	if len(dispo) == 1 {
+		return dispo[0], cout, true
+	}
+	opts := make([]string, len(dispo))
+	for i, v := range dispo {
+		c := v.Appliquer(cout)
+		opts[i] = fmt.Sprintf("%s — Coût: %d or | Mat: %s", v.Nom, c[forgeron.Or], formatMaterials(c))
+		if v.QualiteMin != "" {
+			opts[i] += fmt.Sprintf(" (qualité min. %s)", objet.LibelleQualite(v.QualiteMin))
+		}
+	}
+	idx, cancelled := selectWithArrows(fmt.Sprintf("Variante de fabrication (forge niv. %d):", gs.Joueur.CompetenceForge), opts)
+	if cancelled {
+		return forgeron.VarianteRecette{}, nil, false
+	}
+	return dispo[idx], dispo[idx].Appliquer(cout), true
+}
+
+// choisirVarianteAtelier choisit la variante appliquée à toutes les recettes de l'atelier visuel
+func choisirVarianteAtelier(gs *GameState) (forgeron.VarianteRecette, bool) {
+	dispo := []forgeron.VarianteRecette{}
+	for _, v := range forgeron.VariantesRecette() {
+		if gs.Joueur.CompetenceForge >= v.NiveauRequis {
+			dispo = append(dispo, v)
+		}
+	}
+	if len(dispo) == 1 {
+		return dispo[0], true
+	}
+	opts := make([]string, len(dispo))
+	for i, v := range dispo {
+		effets := []string{}
+		if v.ReductionMats > 0 {
+			effets = append(effets, fmt.Sprintf("-%d%% de matériaux", v.ReductionMats))
+		}
+		if v.SupplementOr > 0 {
+			effets = append(effets, fmt.Sprintf("+%d%% d'or", v.SupplementOr))
+		}
+		if len(v.Supplement) > 0 {
+			effets = append(effets, "+"+formatMaterials(v.Supplement))
+		}
+		if v.QualiteMin != "" {
+			effets = append(effets, "qualité min. "+objet.LibelleQualite(v.QualiteMin))
+		}
+		opts[i] = v.Nom
+		if len(effets) > 0 {
+			opts[i] += " — " + strings.Join(effets, ", ")
+		}
+	}
+	idx, cancelled := selectWithArrows(fmt.Sprintf("Variante de fabrication pour l'atelier (forge niv. %d):", gs.Joueur.CompetenceForge), opts)
+	if cancelled {
+		return forgeron.VarianteRecette{}, false
+	}
+	return dispo[idx], true
+}
+
+// fabriquerEquipement tire la qualité d'un objet forgé et retourne son nom (produireObjet le range).
+// Un objet de qualité normale reste un objet de base; les autres deviennent des exemplaires.
+func fabriquerEquipement(gs *GameState, cle string, armure bool, v forgeron.VarianteRecette) string {
+	q := objet.TirerQualite(gs.Joueur.CompetenceForge, v.QualiteMin)
+	var nom string
+	if q == objet.Normale {
+		if armure {
+			nom = objet.CreerArmure(cle).Nom
+		} else {
+			nom = objet.CreerArme(cle).Nom
+		}
+	} else {
+		nom = nouvelExemplaire(gs, objet.Exemplaire{Cle: cle, Armure: armure, Rarete: objet.Commun, Qualite: q})
+	}
+	return nom
+}
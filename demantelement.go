@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+
+	"sloteriaa/struct/forgeron"
+	"sloteriaa/struct/objet"
+)
+
+// Part (%) des matériaux de la recette rendue au démantèlement
+func partDemantelement(ex objet.Exemplaire) int {
+	pct := 30
+	switch ex.Rarete {
+	case objet.Rare:
+		pct += 10
+	case objet.Epique:
+		pct += 20
+	case objet.Legendaire:
+		pct += 30
+	}
+	pct += 5 * ex.Niveau
+	if pct > 100 {
+		pct = 100
+	}
+	return pct
+}
+
+// tierObjet retourne le tier de donjon où l'objet est trouvé (1 par défaut)
+func tierObjet(cle string) int {
+	for tier := 1; tier <= 4; tier++ {
+		for _, d := range dungeonDrops[tier].Items {
+			if d.ItemName == cle {
+				return tier
+			}
+		}
+	}
+	return 1
+}
+
+// exemplaireOuBase retourne l'exemplaire d'un objet, ou un exemplaire commun de niveau 0 pour un objet de base
+func exemplaireOuBase(gs *GameState, nom string) (objet.Exemplaire, bool) {
+	if ex, ok := exemplaireDe(&gs.Joueur, nom); ok {
+		return ex, true
+	}
+	cle, armure, ok := cleObjet(nom)
+	if !ok {
+		return objet.Exemplaire{}, false
+	}
+	return objet.Exemplaire{Cle: cle, Armure: armure, Rarete: objet.Commun}, true
+}
+
+// rendementDemantelement calcule les matériaux récupérés (hors or)
+func rendementDemantelement(ex objet.Exemplaire) forgeron.Cout {
+	rendu := forgeron.Cout{}
+	cout, ok := coutRecette(ex.Cle)
+	if !ok {
+		return rendu
+	}
+	pct := partDemantelement(ex)
+	for m, q := range cout {
+		if m == forgeron.Or {
+			continue
+		}
+		if n := (q*pct + 50) / 100; n > 0 {
+			rendu[m] = n
+		}
+	}
+	return rendu
+}
+
+// demantelerObjet retire l'objet de l'inventaire et crédite les matériaux
+func demantelerObjet(gs *GameState, nom string) forgeron.Cout {
+	ex, ok := exemplaireOuBase(gs, nom)
+	if !ok || !retirerObjetParNom(&gs.Joueur, nom) {
+		return nil
+	}
+	delete(gs.Joueur.Exemplaires, nom)
+	rendu := rendementDemantelement(ex)
+	for m, q := range rendu {
+		mouvementMateriau(gs, srcDemantelement, nom, m, q)
+	}
+	return rendu
+}
+
+func forgeDemanteler(gs *GameState) {
+	for {
+		idx, cancelled := selectWithArrows("Démantèlement — récupérez une partie des matériaux", []string{
+			"Démanteler un objet",
+			"Tout démanteler sous un tier",
+			"Retour",
+		})
+		if cancelled || idx == 2 {
+			return
+		}
+		if idx == 0 {
+			demantelerUn(gs)
+		} else {
+			demantelerEnMasse(gs)
+		}
+	}
+}
+
+// equipementsNonEquipes liste les armes et armures de l'inventaire qui ne sont pas portées
+func equipementsNonEquipes(gs *GameState) []string {
+	j := &gs.Joueur
+	noms, _ := compterItems(j)
+	res := []string{}
+	for _, item := range noms {
+		if _, _, ok := cleObjet(item); !ok {
+			continue
+		}
+		if estArmeEquipee(j, item) || estArmureEquipee(j, item) {
+			continue
+		}
+		res = append(res, item)
+	}
+	return res
+}
+
+func demantelerUn(gs *GameState) {
+	for {
+		candidats := equipementsNonEquipes(gs)
+		if len(candidats) == 0 {
+			fmt.Println("Aucun équipement non équipé à démanteler.")
+			attendreEntree()
+			return
+		}
+		opts := make([]string, len(candidats))
+		for i, nom := range candidats {
+			ex, _ := exemplaireOuBase(gs, nom)
+			opts[i] = fmt.Sprintf("%s → %s", libelleExemplaire(&gs.Joueur, nom), formatMaterials(rendementDemantelement(ex)))
+		}
+		idx, cancelled := selectWithArrows("Démanteler quel objet ?", opts)
+		if cancelled {
+			return
+		}
+		rendu := demantelerObjet(gs, candidats[idx])
+		fmt.Printf("🔧 %s démantelé : %s\n", candidats[idx], formatMaterials(rendu))
+		attendreEntree()
+	}
+}
+
+// objetPrecieux signale les exemplaires qu'un démantèlement en masse garde par défaut:
+// rareté supérieure à Commun, amélioration ou enchantement
+func objetPrecieux(gs *GameState, nom string) bool {
+	ex, ok := exemplaireDe(&gs.Joueur, nom)
+	return ok && (ex.Rarete != objet.Commun || ex.Niveau > 0 || len(ex.Enchantements) > 0)
+}
+
+// demantelerEnMasse montre la liste des objets et des matériaux rendus, puis demande confirmation.
+// Les objets précieux sont gardés sauf si le joueur choisit de les inclure.
+func demantelerEnMasse(gs *GameState) {
+	opts := []string{}
+	for t := 2; t <= 5; t++ {
+		opts = append(opts, fmt.Sprintf("Tout ce qui est sous le tier %d", t))
+	}
+	idx, cancelled := selectWithArrows("Démanteler tout l'équipement non équipé…", opts)
+	if cancelled {
+		return
+	}
+	seuil := idx + 2
+	ordinaires, precieux := []string{}, []string{}
+	for _, nom := range equipementsNonEquipes(gs) {
+		cle, _, _ := cleObjet(nom)
+		if tierObjet(cle) >= seuil {
+			continue
+		}
+		if objetPrecieux(gs, nom) {
+			precieux = append(precieux, nom)
+		} else {
+			ordinaires = append(ordinaires, nom)
+		}
+	}
+	candidats := ordinaires
+	if len(precieux) > 0 {
+		header := fmt.Sprintf("%d objet(s) rare(s), amélioré(s) ou enchanté(s) sous le tier %d:", len(precieux), seuil)
+		for _, nom := range precieux {
+			header += "\n  ⚠️ " + libelleExemplaire(&gs.Joueur, nom)
+		}
+		i, c := selectWithArrows(header, []string{"Les garder", "Les démanteler aussi"})
+		if c {
+			return
+		}
+		if i == 1 {
+			candidats = append(candidats, precieux...)
+		}
+	}
+	if len(candidats) == 0 {
+		fmt.Println("Rien à démanteler sous ce tier.")
+		attendreEntree()
+		return
+	}
+	// Aperçu: objets détruits et matériaux récupérés
+	total := forgeron.Cout{}
+	count := 0
+	header := "Objets qui seront détruits:"
+	for _, nom := range candidats {
+		n := compterObjet(&gs.Joueur, nom)
+		ex, _ := exemplaireOuBase(gs, nom)
+		rendu := rendementDemantelement(ex)
+		for m, q := range rendu {
+			total[m] += q * n
+		}
+		count += n
+		marque := ""
+		if objetPrecieux(gs, nom) {
+			marque = "⚠️ "
+		}
+		header += fmt.Sprintf("\n  %s%s x%d → %s", marque, libelleExemplaire(&gs.Joueur, nom), n, formatMaterials(multiplierCout(rendu, n)))
+	}
+	header += fmt.Sprintf("\nTotal: %d objet(s) → %s", count, formatMaterials(total))
+	i, c := selectWithArrows(header, []string{"Confirmer le démantèlement", "Annuler"})
+	if c || i == 1 {
+		return
+	}
+	for _, nom := range candidats {
+		// Démanteler tous les exemplaires identiques
+		for compterObjet(&gs.Joueur, nom) > 0 {
+			demantelerObjet(gs, nom)
+		}
+	}
+	fmt.Printf("🔧 %d objets démantelés : %s\n", count, formatMaterials(total))
+	attendreEntree()
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+//go:embed data/dialogues.json
+var donneesDialogues []byte
+
+// PNJ et son arbre de dialogue, décrits dans data/dialogues.json
+type PNJ struct {
+	Cle    string           `json:"cle"`
+	Nom    string           `json:"nom"`
+	Lieu   string           `json:"lieu"`  // lieu de la carte où on le rencontre
+	Debut  string           `json:"debut"` // nœud d'ouverture
+	Noeuds map[string]Noeud `json:"noeuds"`
+}
+
+type Noeud struct {
+	Texte []string `json:"texte"`
+	Choix []Choix  `json:"choix"`
+}
+
+// Choix de réponse: caché si ses conditions ne sont pas remplies; Vers vide termine la conversation
+type Choix struct {
+	Texte      string     `json:"texte"`
+	Vers       string     `json:"vers"`
+	Conditions Conditions `json:"conditions"`
+	Effets     Effets     `json:"effets"`
+}
+
+// Conditions d'un choix; les champs vides ne sont pas vérifiés
+type Conditions struct {
+	NiveauMin        int               `json:"niveau_min"`
+	NiveauMax        int               `json:"niveau_max"`
+	OrMin            int               `json:"or_min"`
+	Classe           string            `json:"classe"`
+	Stats            map[string]int    `json:"stats"` // force, agilite, endurance minimales
+	QueteTerminee    string            `json:"quete_terminee"`
+	QueteNonTerminee string            `json:"quete_non_terminee"`
+	QueteActive      string            `json:"quete_active"`
+	QueteDisponible  string            `json:"quete_disponible"`
+	Reputation       map[string]string `json:"reputation"`     // rang minimal par faction
+	ReputationMax    map[string]string `json:"reputation_max"` // rang maximal par faction
+	Marque           string            `json:"marque"`
+	SansMarque       string            `json:"sans_marque"`
+}
+
+// Effets d'un choix, appliqués quand le joueur le sélectionne
+type Effets struct {
+	Or         int            `json:"or"`
+	Objets     []string       `json:"objets"`
+	Quete      string         `json:"quete"`
+	Recettes   []string       `json:"recettes"`
+	Reputation map[string]int `json:"reputation"`
+	Marque     string         `json:"marque"` // souvenir de la conversation (cadeau déjà fait...)
+}
+
+var (
+	pnjs             []PNJ
+	dialoguesCharges sync.Once
+)
+
+// tousLesPNJ charge et vérifie les dialogues embarqués au premier accès
+func tousLesPNJ() []PNJ {
+	dialoguesCharges.Do(func() {
+		if err := json.Unmarshal(donneesDialogues, &pnjs); err != nil {
+			panic(fmt.Sprintf("dialogues invalides: %s", err))
+		}
+		if err := verifierDialogues(pnjs); err != nil {
+			panic(fmt.Sprintf("dialogues invalides: %s", err))
+		}
+	})
+	return pnjs
+}
+
+// rangParTitre retrouve un rang de faction à partir de son titre (« Honoré »...)
+func rangParTitre(titre string) (int, bool) {
+	for r, p := range paliersFaction {
+		if strings.EqualFold(p.Titre, titre) {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// verifierDialogues contrôle les nœuds, quêtes, factions, recettes et lieux référencés
+func verifierDialogues(ps []PNJ) error {
+	cles := map[string]bool{}
+	for _, p := range ps {
+		cles[p.Cle] = true
+	}
+	for _, q := range toutesLesQuetes() {
+		if q.Donneur != "" && !cles[q.Donneur] {
+			return fmt.Errorf("quête %s: donneur inconnu: %s", q.Cle, q.Donneur)
+		}
+	}
+	for _, p := range ps {
+		if _, ok := lieuParCle(p.Lieu); !ok {
+			return fmt.Errorf("%s: lieu inconnu: %s", p.Cle, p.Lieu)
+		}
+		if _, ok := p.Noeuds[p.Debut]; !ok {
+			return fmt.Errorf("%s: nœud de départ inconnu: %s", p.Cle, p.Debut)
+		}
+		for id, n := range p.Noeuds {
+			for _, c := range n.Choix {
+				if _, ok := p.Noeuds[c.Vers]; c.Vers != "" && !ok {
+					return fmt.Errorf("%s/%s: nœud inconnu: %s", p.Cle, id, c.Vers)
+				}
+				if err := verifierChoix(c); err != nil {
+					return fmt.Errorf("%s/%s: %s", p.Cle, id, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func verifierChoix(c Choix) error {
+	for _, cle := range []string{c.Conditions.QueteTerminee, c.Conditions.QueteNonTerminee, c.Conditions.QueteActive, c.Conditions.QueteDisponible, c.Effets.Quete} {
+		if _, ok := queteParCle(cle); cle != "" && !ok {
+			return fmt.Errorf("quête inconnue: %s", cle)
+		}
+	}
+	for stat := range c.Conditions.Stats {
+		if _, ok := statJoueur(&GameState{}, stat); !ok {
+			return fmt.Errorf("statistique inconnue: %s", stat)
+		}
+	}
+	for _, rangs := range []map[string]string{c.Conditions.Reputation, c.Conditions.ReputationMax} {
+		for f, titre := range rangs {
+			if _, ok := factionParCle(f); !ok {
+				return fmt.Errorf("faction inconnue: %s", f)
+			}
+			if _, ok := rangParTitre(titre); !ok {
+				return fmt.Errorf("rang inconnu: %s", titre)
+			}
+		}
+	}
+	for f := range c.Effets.Reputation {
+		if _, ok := factionParCle(f); !ok {
+			return fmt.Errorf("faction inconnue: %s", f)
+		}
+	}
+	recettes := map[string]bool{}
+	for _, e := range toutesLesRecettes() {
+		recettes[e.Cle] = true
+	}
+	for _, r := range c.Effets.Recettes {
+		if !recettes[r] {
+			return fmt.Errorf("recette inconnue: %s", r)
+		}
+	}
+	return nil
+}
+
+// statJoueur retourne une caractéristique du joueur par son nom dans les fichiers de données
+func statJoueur(gs *GameState, stat string) (int, bool) {
+	switch stat {
+	case "force":
+		return gs.Joueur.Force, true
+	case "agilite":
+		return gs.Joueur.Agilite, true
+	case "endurance":
+		return gs.Joueur.Endurance, true
+	}
+	return 0, false
+}
+
+// conditionsRemplies vérifie toutes les conditions renseignées d'un choix
+func conditionsRemplies(gs *GameState, c Conditions) bool {
+	if c.NiveauMin > 0 && gs.Level < c.NiveauMin {
+		return false
+	}
+	if c.NiveauMax > 0 && gs.Level > c.NiveauMax {
+		return false
+	}
+	if gs.Joueur.Argent < c.OrMin {
+		return false
+	}
+	if c.Classe != "" && gs.Joueur.Classe != c.Classe {
+		return false
+	}
+	for stat, v := range c.Stats {
+		if s, _ := statJoueur(gs, stat); s < v {
+			return false
+		}
+	}
+	if c.QueteTerminee != "" && !queteTerminee(gs, c.QueteTerminee) {
+		return false
+	}
+	if c.QueteNonTerminee != "" && queteTerminee(gs, c.QueteNonTerminee) {
+		return false
+	}
+	if e, ok := gs.Quetes[c.QueteActive]; c.QueteActive != "" && (!ok || e.Terminee) {
+		return false
+	}
+	if q, ok := queteParCle(c.QueteDisponible); c.QueteDisponible != "" && (!ok || !queteDisponible(gs, q)) {
+		return false
+	}
+	for f, titre := range c.Reputation {
+		if r, _ := rangParTitre(titre); !reputationAuMoins(gs, f, r) {
+			return false
+		}
+	}
+	for f, titre := range c.ReputationMax {
+		if r, _ := rangParTitre(titre); rangFaction(gs, f) > r {
+			return false
+		}
+	}
+	if c.Marque != "" && !gs.Marques[c.Marque] {
+		return false
+	}
+	if c.SansMarque != "" && gs.Marques[c.SansMarque] {
+		return false
+	}
+	return true
+}
+
+// appliquerEffets applique les effets d'un choix; retourne true si quelque chose s'est passé
+func appliquerEffets(gs *GameState, pnj PNJ, e Effets) bool {
+	agi := false
+	if e.Or != 0 {
+		mouvementOr(gs, srcDialogue, pnj.Nom, e.Or)
+		if e.Or > 0 {
+			fmt.Printf("💰 Vous recevez %d or.\n", e.Or)
+		} else {
+			fmt.Printf("💰 Vous donnez %d or.\n", -e.Or)
+		}
+		agi = true
+	}
+	for _, obj := range e.Objets {
+		if acquerirObjet(gs, obj) {
+			fmt.Printf("🎁 Vous recevez %s.\n", obj)
+		}
+		agi = true
+	}
+	if e.Quete != "" && accepterQuete(gs, e.Quete) {
+		agi = true
+	}
+	for _, r := range e.Recettes {
+		agi = debloquerRecette(gs, r, pnj.Nom) || agi
+	}
+	for _, f := range factions {
+		if d := e.Reputation[f.Cle]; d != 0 {
+			modifierReputation(gs, f.Cle, d)
+		}
+	}
+	if e.Marque != "" {
+		if gs.Marques == nil {
+			gs.Marques = make(map[string]bool)
+		}
+		gs.Marques[e.Marque] = true
+	}
+	return agi
+}
+
+// parler déroule l'arbre de dialogue d'un PNJ avec selectWithArrows jusqu'à la fin de la conversation
+func parler(gs *GameState, pnj PNJ) {
+	id := pnj.Debut
+	for id != "" {
+		n := pnj.Noeuds[id]
+		choix := []Choix{}
+		opts := []string{}
+		for _, c := range n.Choix {
+			if conditionsRemplies(gs, c.Conditions) {
+				choix = append(choix, c)
+				opts = append(opts, c.Texte)
+			}
+		}
+		header := pnj.Nom + "\n\n" + strings.Join(n.Texte, "\n")
+		if len(choix) == 0 {
+			// Nœud sans réponse possible: la conversation s'arrête là
+			choix = append(choix, Choix{})
+			opts = append(opts, "(Partir)")
+		}
+		idx, cancelled := selectWithArrows(header, opts)
+		if cancelled {
+			return
+		}
+		c := choix[idx]
+		if appliquerEffets(gs, pnj, c.Effets) {
+			attendreEntree()
+		}
+		id = c.Vers
+	}
+}
+
+// EnterParler liste les PNJ présents au lieu actuel
+func EnterParler(gs *GameState) {
+	l := lieuActuel(gs)
+	for {
+		presents := []PNJ{}
+		opts := []string{}
+		for _, p := range tousLesPNJ() {
+			if p.Lieu == l.Cle {
+				presents = append(presents, p)
+				opts = append(opts, p.Nom)
+			}
+		}
+		opts = append(opts, "Retour")
+		idx, cancelled := selectWithArrows(fmt.Sprintf("%s — à qui parler ?", l.Nom), opts)
+		if cancelled || idx == len(presents) {
+			return
+		}
+		parler(gs, presents[idx])
+	}
+}
@@ -111,7 +111,7 @@ var playerSpecialAttacks = []SpecialAttack{
 	},
 }
 
-// Tables de drop par niveau de donjon
+// Tables de drop par niveau de donjon (les matériaux tombent bruts, à raffiner à la forge)
 var dungeonDrops = map[int]struct {
 	Items     []ItemDrop
 	Materials []MaterialDrop
@@ -126,9 +126,9 @@ var dungeonDrops = map[int]struct {
 			{"BottesCuir", 8},
 		},
 		Materials: []MaterialDrop{
-			{forgeron.Cuir, 1, 40},
-			{forgeron.Bois, 1, 30},
-			{forgeron.Fer, 1, 20},
+			{forgeron.PeauBrute, 2, 40},
+			{forgeron.Buche, 1, 30},
+			{forgeron.MineraiDeFer, 3, 20},
 			{forgeron.Or, 50, 60},
 		},
 	},
@@ -143,9 +143,9 @@ var dungeonDrops = map[int]struct {
 			{"BottesCuirRenforce", 6},
 		},
 		Materials: []MaterialDrop{
-			{forgeron.Cuir, 2, 35},
-			{forgeron.Fer, 1, 45},
-			{forgeron.Bois, 2, 25},
+			{forgeron.PeauBrute, 3, 35},
+			{forgeron.MineraiDeFer, 3, 45},
+			{forgeron.Buche, 2, 25},
 			{forgeron.Or, 100, 70},
 		},
 	},
@@ -160,10 +160,10 @@ var dungeonDrops = map[int]struct {
 			{"BottesFer", 5},
 		},
 		Materials: []MaterialDrop{
-			{forgeron.Fer, 2, 50},
-			{forgeron.Cuir, 1, 30},
-			{forgeron.Bois, 1, 20},
-			{forgeron.EssenceMagique, 1, 15},
+			{forgeron.MineraiDeFer, 5, 50},
+			{forgeron.PeauBrute, 2, 30},
+			{forgeron.Buche, 1, 20},
+			{forgeron.CristalBrut, 2, 15},
 			{forgeron.Or, 200, 80},
 		},
 	},
@@ -177,10 +177,10 @@ var dungeonDrops = map[int]struct {
 			{"BottesFerRenforce", 4},
 		},
 		Materials: []MaterialDrop{
-			{forgeron.Fer, 3, 40},
-			{forgeron.EssenceMagique, 2, 25},
-			{forgeron.Cuir, 2, 35},
-			{forgeron.Bois, 2, 30},
+			{forgeron.MineraiDeFer, 6, 40},
+			{forgeron.CristalBrut, 4, 25},
+			{forgeron.PeauBrute, 3, 35},
+			{forgeron.Buche, 2, 30},
 			{forgeron.Or, 300, 90},
 		},
 	},
@@ -196,15 +196,51 @@ var monsterSpecialAttacks = map[string][]SpecialAttack{
 		{Nom: "Morsure", Description: "Attaque basique", Damage: 0, Effects: []StatusEffect{}, Cooldown: 0, CurrentCD: 0},
 		{Nom: "Morsure empoisonnée", Description: "Empoisonne l'ennemi", Damage: -2, Effects: []StatusEffect{{Type: "poison", Duration: 2, Damage: 2, Description: "Empoisonné"}}, Cooldown: 3, CurrentCD: 0},
 	},
+	// Sources de brûlure, poison et saignement sur le joueur, contre lesquelles protègent
+	// les potions d'alchimie (résistance au feu, purification)
+	"Assassin": {
+		{Nom: "Lame sanglante", Description: "Fait saigner l'ennemi", Damage: -2, Effects: []StatusEffect{{Type: "bleed", Duration: 3, Damage: 4, Description: "Saigne"}}, Cooldown: 4, CurrentCD: 0},
+	},
+	"Orc": {
+		{Nom: "Torche enflammée", Description: "Brûle l'ennemi", Damage: -3, Effects: []StatusEffect{{Type: "burn", Duration: 3, Damage: 3, Description: "Brûlé"}}, Cooldown: 4, CurrentCD: 0},
+	},
+	"Dragon ancien": {
+		{Nom: "Souffle ardent", Description: "Embrase l'ennemi", Damage: 5, Effects: []StatusEffect{{Type: "burn", Duration: 4, Damage: 3, Description: "Brûlé"}}, Cooldown: 3, CurrentCD: 0},
+	},
+	"Liche": {
+		{Nom: "Nuée putride", Description: "Empoisonne l'ennemi", Damage: -2, Effects: []StatusEffect{{Type: "poison", Duration: 3, Damage: 5, Description: "Empoisonné"}}, Cooldown: 4, CurrentCD: 0},
+	},
 	"Squelette": {
 		{Nom: "Coup d'os", Description: "Attaque basique", Damage: 0, Effects: []StatusEffect{}, Cooldown: 0, CurrentCD: 0},
 		{Nom: "Malédiction", Description: "Affaiblit l'ennemi", Damage: 0, Effects: []StatusEffect{{Type: "bleed", Duration: 3, Damage: 1, Description: "Maudit"}}, Cooldown: 5, CurrentCD: 0},
 	},
+	// Créatures nocturnes (voir creaturesNocturnes)
+	"Chauve-souris vampire": {
+		{Nom: "Morsure", Description: "Attaque basique", Damage: 0, Effects: []StatusEffect{}, Cooldown: 0, CurrentCD: 0},
+		{Nom: "Succion", Description: "Fait saigner l'ennemi", Damage: -2, Effects: []StatusEffect{{Type: "bleed", Duration: 2, Damage: 2, Description: "Saigne"}}, Cooldown: 3, CurrentCD: 0},
+	},
+	"Goule": {
+		{Nom: "Griffes putrides", Description: "Empoisonne l'ennemi", Damage: -2, Effects: []StatusEffect{{Type: "poison", Duration: 3, Damage: 3, Description: "Empoisonné"}}, Cooldown: 3, CurrentCD: 0},
+	},
+	"Spectre": {
+		{Nom: "Toucher glacial", Description: "Étourdit l'ennemi", Damage: -4, Effects: []StatusEffect{{Type: "stun", Duration: 1, Damage: 0, Description: "Étourdi"}}, Cooldown: 4, CurrentCD: 0},
+	},
+	"Loup-garou sauvage": {
+		{Nom: "Lacération", Description: "Fait saigner l'ennemi", Damage: 2, Effects: []StatusEffect{{Type: "bleed", Duration: 3, Damage: 5, Description: "Saigne"}}, Cooldown: 3, CurrentCD: 0},
+	},
+	"Seigneur vampire": {
+		{Nom: "Étreinte du sang", Description: "Fait saigner l'ennemi", Damage: 0, Effects: []StatusEffect{{Type: "bleed", Duration: 3, Damage: 6, Description: "Saigne"}}, Cooldown: 3, CurrentCD: 0},
+		{Nom: "Regard hypnotique", Description: "Étourdit l'ennemi", Damage: -5, Effects: []StatusEffect{{Type: "stun", Duration: 1, Damage: 0, Description: "Étourdi"}}, Cooldown: 5, CurrentCD: 0},
+	},
 }
 
 func EnterDungeon(gs *GameState) {
 	for {
-		idx, cancelled := selectWithArrows("Donjon — choisissez une salle:", []string{
+		header := fmt.Sprintf("Donjon — %s — PV %d/%d — choisissez une salle:", libelleHeure(gs), gs.Joueur.PVActuels, gs.Joueur.PVMax)
+		if estNuit(gs) {
+			header += "\n🌙 La nuit, les monstres sont plus forts et des créatures nocturnes rôdent."
+		}
+		idx, cancelled := selectWithArrows(header, []string{
 			"Couloir bas-niveau",
 			"Couloir novice (lvl 5)",
 			"Couloir intermédiaire (lvl 10)",
@@ -215,6 +251,7 @@ func EnterDungeon(gs *GameState) {
 		if cancelled {
 			return
 		}
+		var issue issueCombat
 		switch idx {
 		case 0:
 			if gs.Level < requiredLevelForTier(1) {
@@ -222,46 +259,86 @@ func EnterDungeon(gs *GameState) {
 				attendreEntree()
 				continue
 			}
-			fightRoom(gs, 1)
+			issue = fightRoom(gs, 1)
 		case 1:
 			if gs.Level < 5 {
 				fmt.Println("Niveau insuffisant (niveau requis: 5).")
 				attendreEntree()
 				continue
 			}
-			fightRoom(gs, 2)
+			issue = fightRoom(gs, 2)
 		case 2:
 			if gs.Level < 10 {
 				fmt.Println("Niveau insuffisant (niveau requis: 10).")
 				attendreEntree()
 				continue
 			}
-			fightRoom(gs, 3)
+			issue = fightRoom(gs, 3)
 		case 3:
 			if gs.Level < 15 {
 				fmt.Println("Niveau insuffisant (niveau requis: 15).")
 				attendreEntree()
 				continue
 			}
-			fightRoom(gs, 4)
+			issue = fightRoom(gs, 4)
 		case 4:
 			if gs.Level < 20 {
 				fmt.Println("Niveau insuffisant (niveau requis: 20).")
 				attendreEntree()
 				continue
 			}
-			bossFight(gs)
+			issue = bossFight(gs)
 		case 5:
 			return
 		}
+		finExpedition(gs)
+		// Une défaite ramène au village: l'exploration du donjon s'arrête là
+		if issue == defaite {
+			return
+		}
 	}
 }
 
-func fightRoom(gs *GameState, tier int) {
-	if gs.Joueur.PVActuels <= 0 {
-		gs.Joueur.PVActuels = gs.Joueur.PVMax
+// Issue d'un combat
+type issueCombat int
+
+const (
+	victoire issueCombat = iota
+	fuite
+	defaite
+)
+
+// Conséquences d'une défaite: on se réveille en ville, affaibli et délesté d'une partie de son or
+const (
+	pvApresDefaite = 10 // % des PV max restants
+	orPerduDefaite = 10 // % de l'or perdu
+)
+
+// subirDefaite ramène le joueur en ville avec peu de PV: seuls l'auberge et les potions soignent
+func subirDefaite(gs *GameState) {
+	j := &gs.Joueur
+	j.Statuts = nil
+	gererBuffsApresCombat(gs)
+	j.PVActuels = max(1, j.PVMax*pvApresDefaite/100)
+	if perte := j.Argent * orPerduDefaite / 100; perte > 0 {
+		mouvementOr(gs, srcDefaite, "Or perdu en tombant au combat", -perte)
+		fmt.Printf("💰 Vous avez perdu %d or. PV %d/%d: reposez-vous à l'auberge.\n", perte, j.PVActuels, j.PVMax)
+	} else {
+		fmt.Printf("PV %d/%d: reposez-vous à l'auberge.\n", j.PVActuels, j.PVMax)
+	}
+	retourVille(gs)
+}
+
+func fightRoom(gs *GameState, tier int) issueCombat {
+	return combattre(gs, tier, generateMonster(tier))
+}
+
+// combattre mène un combat contre un monstre du tier donné (récompenses comprises)
+func combattre(gs *GameState, tier int, mon Monster) issueCombat {
+	appliquerNuit(gs, &mon, tier)
+	if estNuit(gs) {
+		fmt.Println("🌙 Il fait nuit: les monstres sont plus féroces.")
 	}
-	mon := generateMonster(tier)
 	fmt.Printf("Un %s apparaît ! (PV %d, ATK %d)\n", mon.Nom, mon.PV, mon.Attaque)
 	playerHP := gs.Joueur.PVActuels
 	monsterStunned := false
@@ -272,6 +349,10 @@ func fightRoom(gs *GameState, tier int) {
 	for mon.PV > 0 && playerHP > 0 {
 		// Appliquer les effets de statut du monstre
 		applyStatusEffects(&mon, mon.PVMax)
+		playerHP = appliquerStatutsJoueur(gs, playerHP)
+		if playerHP <= 0 {
+			break
+		}
 
 		// Décrémenter les cooldowns des monstres
 		if attacks, exists := monsterSpecialAttacks[mon.Nom]; exists {
@@ -305,6 +386,11 @@ func fightRoom(gs *GameState, tier int) {
 			}
 			fmt.Printf("Vous infligez %d dégâts. (PV monstre %d)\n", dmg, mon.PV)
 			attackedOnce = true
+			playerHP = volDeVie(gs, dmg, playerHP)
+			if effetsEnchantementsAuToucher(gs, &mon) {
+				monsterStunned = true
+				fmt.Println("✦ L'enchantement étourdit le monstre !")
+			}
 		}
 		if didStun {
 			monsterStunned = true
@@ -322,6 +408,8 @@ func fightRoom(gs *GameState, tier int) {
 		if monsterStunned {
 			fmt.Println("Le monstre est étourdi et ne peut pas attaquer.")
 			monsterStunned = false
+		} else if rand.Intn(100) < chanceEsquive(gs) {
+			fmt.Printf("Vous esquivez l'attaque de %s !\n", mon.Nom)
 		} else {
 			mincoming, specialName, stunPlayer := monsterAction(mon)
 			reduction := gs.Joueur.Endurance / 3
@@ -329,6 +417,7 @@ func fightRoom(gs *GameState, tier int) {
 				reduction = mincoming - 1
 			}
 			mincoming -= reduction
+			mincoming -= mincoming * protectionContre(gs, mon.Type) / 100
 			if playerGuard {
 				mincoming = mincoming / 2
 			}
@@ -336,11 +425,16 @@ func fightRoom(gs *GameState, tier int) {
 				mincoming = 1
 			}
 			playerHP -= mincoming
+			if stunPlayer && resisteStatut(gs) {
+				stunPlayer = false
+				fmt.Println("Votre équipement vous protège de l'étourdissement !")
+			}
 			if specialName != "" {
 				fmt.Printf("%s utilise %s et inflige %d (PV %d/%d)\n", mon.Nom, specialName, mincoming, max0(playerHP), gs.Joueur.PVMax)
 				if stunPlayer {
 					fmt.Println("Vous êtes étourdi pour 1 tour !")
 				}
+				infligerStatutsJoueur(gs, mon, specialName)
 			} else {
 				fmt.Printf("Le %s vous touche pour %d (PV %d/%d)\n", mon.Nom, mincoming, max0(playerHP), gs.Joueur.PVMax)
 			}
@@ -358,31 +452,32 @@ func fightRoom(gs *GameState, tier int) {
 	}
 	if playerHP <= 0 {
 		fmt.Println("Vous tombez inconscient... Vous êtes ramené à la ville.")
-		gs.Joueur.PVActuels = gs.Joueur.PVMax
+		subirDefaite(gs)
 		fmt.Println("(Appuyez sur Entrée pour revenir)")
 		attendreEntree()
-		return
+		return defaite
 	}
 	gs.Joueur.PVActuels = playerHP
+	gererBuffsApresCombat(gs)
 	if fled {
 		fmt.Println("Vous avez fui. Aucune récompense.")
 		fmt.Println("(Appuyez sur Entrée pour revenir)")
 		attendreEntree()
-		return
+		return fuite
 	}
 	fmt.Println("Victoire !")
 	reward(gs, tier)
 	gainXP(gs, xpForTier(tier))
+	signalerQuete(gs, objTuer, mon.Nom, 1)
+	signalerQuete(gs, objTier, "", tier)
 	fmt.Println("(Appuyez sur Entrée pour revenir)")
 	attendreEntree()
+	return victoire
 }
 
-func bossFight(gs *GameState) {
-	if gs.Joueur.PVActuels <= 0 {
-		gs.Joueur.PVActuels = gs.Joueur.PVMax
-	}
+func bossFight(gs *GameState) issueCombat {
 	fmt.Println("Vous entrez dans la salle interdite... Votre mère, métamorphosée, se dresse devant vous !")
-	mon := Monster{Nom: "Mère métamorphe", PV: 400, Attaque: 35, Type: "Boss"}
+	mon := Monster{Nom: "Mère métamorphe", PV: 400, PVMax: 400, Attaque: 35, Type: "Boss"}
 	playerHP := gs.Joueur.PVActuels
 	monsterStunned := false
 	playerGuard := false
@@ -390,6 +485,13 @@ func bossFight(gs *GameState) {
 	fled := false
 	attackedOnce := false
 	for mon.PV > 0 && playerHP > 0 {
+		// Statuts posés par les enchantements
+		applyStatusEffects(&mon, mon.PVMax)
+		playerHP = appliquerStatutsJoueur(gs, playerHP)
+		if playerHP <= 0 {
+			break
+		}
+
 		renderBattle(gs, mon, playerHP, monsterStunned, playerGuard, playerStunned)
 		dmg, didStun, didGuard, didUse := 0, false, false, false
 		if playerStunned {
@@ -413,6 +515,11 @@ func bossFight(gs *GameState) {
 			}
 			fmt.Printf("Vous infligez %d dégâts. (PV monstre %d)\n", dmg, mon.PV)
 			attackedOnce = true
+			playerHP = volDeVie(gs, dmg, playerHP)
+			if effetsEnchantementsAuToucher(gs, &mon) {
+				monsterStunned = true
+				fmt.Println("✦ L'enchantement étourdit le monstre !")
+			}
 		}
 		if didStun {
 			monsterStunned = true
@@ -430,6 +537,8 @@ func bossFight(gs *GameState) {
 		if monsterStunned {
 			fmt.Println("Le monstre est étourdi et ne peut pas attaquer.")
 			monsterStunned = false
+		} else if rand.Intn(100) < chanceEsquive(gs) {
+			fmt.Printf("Vous esquivez l'attaque de %s !\n", mon.Nom)
 		} else {
 			mincoming, specialName, stunPlayer := monsterAction(mon)
 			reduction := gs.Joueur.Endurance / 3
@@ -437,6 +546,7 @@ func bossFight(gs *GameState) {
 				reduction = mincoming - 1
 			}
 			mincoming -= reduction
+			mincoming -= mincoming * protectionContre(gs, mon.Type) / 100
 			if playerGuard {
 				mincoming = mincoming / 2
 			}
@@ -444,11 +554,16 @@ func bossFight(gs *GameState) {
 				mincoming = 1
 			}
 			playerHP -= mincoming
+			if stunPlayer && resisteStatut(gs) {
+				stunPlayer = false
+				fmt.Println("Votre équipement vous protège de l'étourdissement !")
+			}
 			if specialName != "" {
 				fmt.Printf("%s utilise %s et inflige %d (PV %d/%d)\n", mon.Nom, specialName, mincoming, max0(playerHP), gs.Joueur.PVMax)
 				if stunPlayer {
 					fmt.Println("Vous êtes étourdi pour 1 tour !")
 				}
+				infligerStatutsJoueur(gs, mon, specialName)
 			} else {
 				fmt.Printf("%s vous touche pour %d (PV %d/%d)\n", mon.Nom, mincoming, max0(playerHP), gs.Joueur.PVMax)
 			}
@@ -461,18 +576,19 @@ func bossFight(gs *GameState) {
 		playerGuard = false
 	}
 	if playerHP <= 0 {
-		fmt.Println("Vous tombez... Le destin attend une autre tentative.")
-		gs.Joueur.PVActuels = gs.Joueur.PVMax
+		fmt.Println("Vous tombez... Le destin attend une autre tentative. Vous êtes ramené à la ville.")
+		subirDefaite(gs)
 		fmt.Println("(Appuyez sur Entrée pour revenir)")
 		attendreEntree()
-		return
+		return defaite
 	}
 	gs.Joueur.PVActuels = playerHP
+	gererBuffsApresCombat(gs)
 	if fled {
 		fmt.Println("Vous avez fui. Aucune récompense.")
 		fmt.Println("(Appuyez sur Entrée pour revenir)")
 		attendreEntree()
-		return
+		return fuite
 	}
 	// Messages de fin avant l'animation
 	fmt.Println("Votre mère reprend forme humaine. Ses yeux redeviennent doux. Elle vous serre dans ses bras.")
@@ -488,8 +604,11 @@ func bossFight(gs *GameState) {
 	// Récompenses
 	reward(gs, 5)
 	gainXP(gs, xpForBoss())
+	signalerQuete(gs, objTuer, mon.Nom, 1)
+	signalerQuete(gs, objTier, "", 5)
 	fmt.Println("(Appuyez sur Entrée pour revenir)")
 	attendreEntree()
+	return victoire
 }
 
 // Petite animation de fin: la métamorphe redevient humaine puis FIN
@@ -585,6 +704,7 @@ func renderBattle(gs *GameState, mon Monster, playerHP int, monsterStunned bool,
 	if playerStunned {
 		pStatus += " [Étourdi]"
 	}
+	pStatus += statutsJoueur(&gs.Joueur)
 	eStatus := displayStatusEffects(&mon)
 
 	// Valeurs calculées
@@ -592,9 +712,11 @@ func renderBattle(gs *GameState, mon Monster, playerHP int, monsterStunned bool,
 	pDef := computePlayerDefense(gs)
 	pHP := fmt.Sprintf("%d/%d", max0(playerHP), gs.Joueur.PVMax)
 	eHP := fmt.Sprintf("%d", max0(mon.PV))
-	weap := gs.Joueur.Attaque
+	weap := truncate(gs.Joueur.Attaque, 30)
 	if weap == "" {
 		weap = "(mains nues)"
+	} else if ex, ok := exemplaireDe(&gs.Joueur, gs.Joueur.Attaque); ok {
+		weap = objet.Colorer(ex.Rarete, weap)
 	}
 
 	// Taille du terminal (fallback = 100)
@@ -622,7 +744,7 @@ func renderBattle(gs *GameState, mon Monster, playerHP int, monsterStunned bool,
 	fmt.Printf("%-*s %s\n", leftWidth, leftStats, rightStats)
 
 	// Ligne 3 : arme / force (on garde à gauche)
-	leftWeapon := fmt.Sprintf("Force: %d | Arme: %s", gs.Joueur.Force, truncate(weap, 20))
+	leftWeapon := fmt.Sprintf("Force: %d | Arme: %s", gs.Joueur.Force, weap)
 	fmt.Printf("%-*s\n\n", leftWidth, leftWeapon)
 }
 
@@ -636,19 +758,12 @@ func truncate(s string, n int) string {
 
 // Somme la défense des armures équipées du joueur
 func computePlayerDefense(gs *GameState) int {
-	if len(gs.Joueur.ArmuresEquipees) == 0 {
-		return 0
-	}
-	keys := []string{
-		"CasqueCuir", "CasqueCuirRenforce", "CasqueFer", "CasqueFerRenforce",
-		"PlastronCuir", "PlastronCuirRenforce", "PlastronFer", "PlastronFerRenforce",
-		"PantalonCuir", "PantalonCuirRenforce", "PantalonFer", "PantalonFerRenforce",
-		"BottesCuir", "BottesCuirRenforce", "BottesFer", "BottesFerRenforce",
-	}
 	total := 0
-	for _, k := range keys {
-		ar := objet.CreerArmure(k)
-		if gs.Joueur.ArmuresEquipees[ar.Nom] {
+	for nom, equipe := range gs.Joueur.ArmuresEquipees {
+		if !equipe {
+			continue
+		}
+		if ar, ok := armureDe(&gs.Joueur, nom); ok {
 			total += ar.EffetDefense
 		}
 	}
@@ -683,10 +798,11 @@ func playerAction(gs *GameState) (int, bool, bool, bool) {
 	switch idx {
 	case 0: // Attaquer standard avec petit critique
 		base := computePlayerAttack(gs)
-		critChance := 10 + gs.Joueur.Agilite
+		critChance := 10 + agiliteEffective(gs)
 		if critChance > 50 {
 			critChance = 50
 		}
+		critChance += bonusEquipement(&gs.Joueur, objet.AffixeCritique)
 		if rand.Intn(100) < critChance {
 			base = int(float64(base) * 1.5)
 			fmt.Println("Coup critique !")
@@ -746,9 +862,11 @@ func generateMonster(tier int) Monster {
 }
 
 func computePlayerAttack(gs *GameState) int {
-	name := gs.Joueur.Attaque
-	// Inclure les buffs temporaires dans le calcul de la force
-	totalForce := gs.Joueur.Force + gs.Joueur.BuffForce
+	name := objet.NomDeBase(gs.Joueur.Attaque)
+	// Les affixes de force comptent comme de la force de base; les buffs temporaires
+	// ne s'ajoutent qu'aux coups à mains nues
+	force := gs.Joueur.Force + bonusEquipement(&gs.Joueur, objet.AffixeForce)
+	totalForce := force + gs.Joueur.BuffForce
 	base := 12 + totalForce/2
 
 	// Bonus de transformation du loup-garou
@@ -756,7 +874,12 @@ func computePlayerAttack(gs *GameState) int {
 	if gs.Joueur.Classe == "Loups-Garou" {
 		if float64(gs.Joueur.PVActuels)/float64(gs.Joueur.PVMax) <= 0.3 {
 			transformationBonus = 1.5 // +50% d'attaque
-			fmt.Println("🐺 Le loup-garou se transforme ! Puissance décuplée !")
+			if estNuit(gs) {
+				transformationBonus = transformationNuit
+				fmt.Println("🌕 Sous la lune, le loup-garou se transforme ! Sa rage est sans limite !")
+			} else {
+				fmt.Println("🐺 Le loup-garou se transforme ! Puissance décuplée !")
+			}
 		}
 	}
 
@@ -765,15 +888,18 @@ func computePlayerAttack(gs *GameState) int {
 	}
 
 	// try map to known weapons
-	keys := []string{"EpeeRouillee", "EpeeFer", "EpeeMagique", "EpeeCourte", "Hache", "HacheDeCombat", "HacheDeBataille", "ArcBois", "ArcLong", "ArcElfe"}
+	keys := []string{"EpeeRouillee", "EpeeFer", "EpeeMagique", "EpeeCourte", "Hache", "HacheDeCombat", "HacheDeBataille", "HacheDeLaConfrerie", "EpeeDuMaitreForgeron", "ArcBois", "ArcLong", "ArcElfe"}
 	for _, k := range keys {
 		w := objet.CreerArme(k)
 		if strings.EqualFold(name, w.Nom) {
+			// Stats réelles de l'exemplaire équipé (améliorations)
+			if ex, ok := exemplaireDe(&gs.Joueur, gs.Joueur.Attaque); ok {
+				w = ex.StatsArme()
+			}
 			// Bonus d'agilité pour les armes rapides (épées et arcs)
 			agilityBonus := 0
 			if strings.Contains(k, "Epee") || strings.Contains(k, "Arc") {
-				totalAgilite := gs.Joueur.Agilite + gs.Joueur.BuffAgilite
-				agilityBonus = totalAgilite / 3 // +1 dégât tous les 3 points d'agilité
+				agilityBonus = agiliteEffective(gs) / 3 // +1 dégât tous les 3 points d'agilité
 			}
 
 			// Bonus spécial du Bûcheron avec les haches
@@ -782,51 +908,85 @@ func computePlayerAttack(gs *GameState) int {
 				bucheronBonus = 5 // +5 dégâts avec les haches
 			}
 
-			return int(float64(w.EffetAttaque+gs.Joueur.Force/2+agilityBonus+bucheronBonus) * transformationBonus)
+			return int(float64(w.EffetAttaque+force/2+agilityBonus+bucheronBonus) * transformationBonus)
 		}
 	}
 
 	// Griffes du loup-garou transformé
 	if strings.Contains(name, "Griffes") {
-		clawDamage := 25 + gs.Joueur.Force/2
+		clawDamage := 25 + force/2
 		return int(float64(clawDamage) * transformationBonus)
 	}
 
 	return int(float64(base+3) * transformationBonus)
 }
 
-// Fonction pour gérer les drops d'objets et matériaux
-func processDrops(gs *GameState, tier int) {
-	if tier > 4 {
-		tier = 4 // Boss utilise les drops du niveau 4
+// Vol de vie des affixes: soigne un pourcentage des dégâts infligés
+func volDeVie(gs *GameState, dmg int, playerHP int) int {
+	pct := bonusEquipement(&gs.Joueur, objet.AffixeVolDeVie)
+	soin := dmg * pct / 100
+	if soin <= 0 {
+		return playerHP
+	}
+	playerHP += soin
+	if playerHP > gs.Joueur.PVMax {
+		playerHP = gs.Joueur.PVMax
 	}
+	gs.Joueur.PVActuels = playerHP
+	fmt.Printf("🩸 Vol de vie: +%d PV\n", soin)
+	return playerHP
+}
+
+// Résistance aux statuts des affixes (plafonnée à 75%)
+func resisteStatut(gs *GameState) bool {
+	chance := bonusEquipement(&gs.Joueur, objet.AffixeResistance)
+	if chance > 75 {
+		chance = 75
+	}
+	return rand.Intn(100) < chance
+}
 
-	drops, exists := dungeonDrops[tier]
-	if !exists {
-		return
+// Agilité utilisée en combat: base + buff, moins le malus de surcharge
+func agiliteEffective(gs *GameState) int {
+	agi := gs.Joueur.Agilite + gs.Joueur.BuffAgilite - malusSurcharge(&gs.Joueur)
+	if agi < 0 {
+		return 0
 	}
+	return agi
+}
 
-	fmt.Println("\n🎁 Butin trouvé :")
+// Chance d'esquive: 2% par point d'agilité effective (max 40%)
+func chanceEsquive(gs *GameState) int {
+	chance := 2 * agiliteEffective(gs)
+	if chance > 40 {
+		chance = 40
+	}
+	return chance
+}
 
-	// Pas de drops d'équipements ici (uniquement matériaux)
+// finExpedition fait avancer le temps du jeu après chaque salle: commandes du forgeron, cours du marché et horloge
+func finExpedition(gs *GameState) {
+	avancerCommandes(gs)
+	deriveMarche(gs)
+	avancerTemps(gs, heuresParExpedition)
+}
 
-	// Drops de matériaux
-	for _, materialDrop := range drops.Materials {
-		if rand.Intn(100) < materialDrop.Chance {
-			gs.Mats[string(materialDrop.Material)] += materialDrop.Quantity
-			fmt.Printf("  📦 %s x%d\n", materialDrop.Material, materialDrop.Quantity)
+// dropMateriauxBruts tire les matériaux bruts de la table du tier (l'or est déjà donné par reward)
+func dropMateriauxBruts(gs *GameState, tier int) {
+	if tier > 4 {
+		tier = 4
+	}
+	for _, d := range dungeonDrops[tier].Materials {
+		if forgeron.EstBrut(d.Material) && rand.Intn(100) < d.Chance {
+			mouvementMateriau(gs, srcButin, fmt.Sprintf("Tier %d", tier), d.Material, d.Quantity)
+			fmt.Printf("⛏️ Vous récoltez %s x%d !\n", d.Material, d.Quantity)
 		}
 	}
-
-	// Récompense d'or de base
-	baseGold := tier * 50
-	gs.Joueur.Argent += baseGold
-	fmt.Printf("  💰 %d or\n", baseGold)
 }
 
 // Fonctions utilitaires pour identifier les types d'objets
 func isWeapon(itemName string) bool {
-	weapons := []string{"EpeeRouillee", "EpeeCourte", "EpeeFer", "EpeeMagique", "Hache", "HacheDeCombat", "HacheDeBataille", "ArcBois", "ArcLong", "ArcElfe"}
+	weapons := []string{"EpeeRouillee", "EpeeCourte", "EpeeFer", "EpeeMagique", "Hache", "HacheDeCombat", "HacheDeBataille", "HacheDeLaConfrerie", "EpeeDuMaitreForgeron", "ArcBois", "ArcLong", "ArcElfe"}
 	for _, w := range weapons {
 		if w == itemName {
 			return true
@@ -836,7 +996,7 @@ func isWeapon(itemName string) bool {
 }
 
 func isArmor(itemName string) bool {
-	armors := []string{"CasqueCuir", "CasqueCuirRenforce", "CasqueFer", "CasqueFerRenforce", "PlastronCuir", "PlastronCuirRenforce", "PlastronFer", "PlastronFerRenforce", "PantalonCuir", "PantalonCuirRenforce", "PantalonFer", "PantalonFerRenforce", "BottesCuir", "BottesCuirRenforce", "BottesFer", "BottesFerRenforce"}
+	armors := []string{"CasqueCuir", "CasqueCuirRenforce", "CasqueFer", "CasqueFerRenforce", "PlastronCuir", "PlastronCuirRenforce", "PlastronFer", "PlastronFerRenforce", "PlastronDeLaConfrerie", "PantalonCuir", "PantalonCuirRenforce", "PantalonFer", "PantalonFerRenforce", "BottesCuir", "BottesCuirRenforce", "BottesFer", "BottesFerRenforce"}
 	for _, a := range armors {
 		if a == itemName {
 			return true
@@ -848,24 +1008,36 @@ func isArmor(itemName string) bool {
 func reward(gs *GameState, tier int) {
 	// Or de base selon le tier
 	baseGold := tier * 20
-	gs.Joueur.Argent += baseGold
+	mouvementOr(gs, srcRecompense, fmt.Sprintf("Tier %d", tier), baseGold)
 	fmt.Printf("💰 Vous obtenez %d or !\n", baseGold)
+	modifierReputation(gs, factionGarde, repParTierMonstre*tier)
 
 	// Items de loot des monstres (taux de drop bas)
 	if rand.Intn(100) < 25 { // 25% de chance d'obtenir un item vendable (loot de monstre)
 		item := getRandomLootItemForTier(tier)
-		gs.Joueur.Inventaire = append(gs.Joueur.Inventaire, item)
-		fmt.Printf("🗡️ Vous obtenez %s !\n", item)
+		if acquerirObjet(gs, item) {
+			fmt.Printf("🗡️ Vous obtenez %s !\n", item)
+		}
 	}
 
+	// Équipements avec rareté et affixes
+	dropEquipement(gs, tier)
+
 	// Matériaux (taux de drop bas)
 	for _, mat := range getMaterialsForTier(tier) {
 		// taux bas: 30% par matériau listé
 		if rand.Intn(100) < 30 {
-			gs.Joueur.Materiaux[mat]++
+			mouvementMateriau(gs, srcButin, fmt.Sprintf("Tier %d", tier), mat, 1)
 			fmt.Printf("📦 Vous obtenez %s !\n", mat)
 		}
 	}
+
+	// Matériaux bruts de la table du tier
+	dropMateriauxBruts(gs, tier)
+
+	// Recettes: palier atteint et parchemins
+	debloquerRecettesTier(gs, tier)
+	dropParchemin(gs, tier)
 }
 
 func gainXP(gs *GameState, amount int) {
@@ -1011,6 +1183,92 @@ func applyStatusEffects(mon *Monster, maxHP int) {
 	}
 }
 
+// Statuts du joueur: les attaques spéciales des monstres posent poison, brûlure ou saignement
+// (Joueur.Statuts, en tours), qui infligent chaque tour un % des PV max, comme pour les monstres.
+// L'antidote et la purification les guérissent; les affixes de résistance peuvent les éviter.
+var degatsStatutJoueur = map[string]int{"poison": 5, "burn": 3, "bleed": 4}
+
+var libellesStatutJoueur = map[string]string{"poison": "Empoisonné", "burn": "Brûlé", "bleed": "Saigne"}
+
+// appliquerStatutsJoueur applique en début de tour les statuts et la régénération du joueur
+func appliquerStatutsJoueur(gs *GameState, playerHP int) int {
+	j := &gs.Joueur
+	for _, t := range []string{"poison", "burn", "bleed"} {
+		tours := j.Statuts[t]
+		if tours <= 0 {
+			continue
+		}
+		dmg := max(1, j.PVMax*degatsStatutJoueur[t]/100)
+		playerHP -= dmg
+		fmt.Printf("☠️ %s : vous perdez %d PV.\n", libellesStatutJoueur[t], dmg)
+		if tours == 1 {
+			delete(j.Statuts, t)
+		} else {
+			j.Statuts[t] = tours - 1
+		}
+	}
+	if j.RegenTours > 0 && playerHP > 0 {
+		playerHP += j.RegenPV
+		if playerHP > j.PVMax {
+			playerHP = j.PVMax
+		}
+		j.RegenTours--
+		fmt.Printf("💚 Régénération : +%d PV.\n", j.RegenPV)
+	}
+	return playerHP
+}
+
+// infligerStatutsJoueur pose sur le joueur les statuts de l'attaque spéciale utilisée
+func infligerStatutsJoueur(gs *GameState, mon Monster, specialName string) {
+	j := &gs.Joueur
+	for _, attack := range monsterSpecialAttacks[mon.Nom] {
+		if attack.Nom != specialName {
+			continue
+		}
+		for _, effect := range attack.Effects {
+			if _, ok := degatsStatutJoueur[effect.Type]; !ok {
+				continue
+			}
+			switch {
+			case j.Purification > 0:
+				fmt.Println("🌿 La purification vous protège !")
+			case effect.Type == "burn" && j.ResistanceFeu > 0:
+				fmt.Println("🔥 Vous résistez aux flammes !")
+			case resisteStatut(gs):
+				fmt.Printf("Votre équipement vous protège (%s) !\n", effect.Description)
+			default:
+				if j.Statuts == nil {
+					j.Statuts = map[string]int{}
+				}
+				if effect.Duration > j.Statuts[effect.Type] {
+					j.Statuts[effect.Type] = effect.Duration
+				}
+				fmt.Printf("Vous êtes %s pour %d tours !\n", strings.ToLower(effect.Description), effect.Duration)
+			}
+		}
+	}
+}
+
+// statutsJoueur retourne les statuts actifs du joueur pour l'affichage du combat
+func statutsJoueur(j *personnage.Personnage) string {
+	s := ""
+	for _, t := range []string{"poison", "burn", "bleed"} {
+		if j.Statuts[t] > 0 {
+			s += fmt.Sprintf(" [%s]", libellesStatutJoueur[t])
+		}
+	}
+	if j.RegenTours > 0 {
+		s += " [Régénération]"
+	}
+	if j.ResistanceFeu > 0 {
+		s += " [Anti-feu]"
+	}
+	if j.Purification > 0 {
+		s += " [Purifié]"
+	}
+	return s
+}
+
 // Fonction pour afficher les statuts actifs
 func displayStatusEffects(mon *Monster) string {
 	statuses := []string{}
@@ -1147,7 +1405,7 @@ func menuPotion(gs *GameState) (int, bool, bool, bool) {
 	descriptions := []string{}
 
 	// Vérifier quelles potions sont dans l'inventaire
-	potionsPossibles := []string{"potion", "potion majeure", "potion force", "potion agilite", "potion endurance", "antidote", "elixir vie"}
+	potionsPossibles := []string{"potion", "potion majeure", "potion force", "potion agilite", "potion endurance", "antidote", "elixir vie", "potion resistance feu", "potion purification", "potion regeneration"}
 
 	for _, potion := range potionsPossibles {
 		// Vérifier si le joueur a cette potion
@@ -1170,6 +1428,12 @@ func menuPotion(gs *GameState) (int, bool, bool, bool) {
 					descriptions = append(descriptions, "Antidote (Guérit statuts)")
 				case "elixir vie":
 					descriptions = append(descriptions, "Élixir de vie (+100 PV)")
+				case "potion resistance feu":
+					descriptions = append(descriptions, "Potion de résistance au feu (Immunité brûlure, 3 combats)")
+				case "potion purification":
+					descriptions = append(descriptions, "Potion de purification (Guérit et immunise, 2 combats)")
+				case "potion regeneration":
+					descriptions = append(descriptions, "Potion de régénération (+8 PV/tour, 10 tours)")
 				}
 				break
 			}
@@ -1209,14 +1473,22 @@ func menuPotion(gs *GameState) (int, bool, bool, bool) {
 		utiliserAntidote(&gs.Joueur)
 	case "elixir vie":
 		utiliserElixirVie(&gs.Joueur)
+	case "potion resistance feu":
+		utiliserPotionResistanceFeu(&gs.Joueur)
+	case "potion purification":
+		utiliserPotionPurification(&gs.Joueur)
+	case "potion regeneration":
+		utiliserPotionRegeneration(&gs.Joueur)
 	}
 
 	// Retourner si une potion a été utilisée (pour les potions de soin)
-	used := gs.Joueur.PVActuels > before || potionChoisie == "potion force" || potionChoisie == "potion agilite" || potionChoisie == "potion endurance" || potionChoisie == "antidote"
+	used := gs.Joueur.PVActuels > before || potionChoisie != "potion" && potionChoisie != "potion majeure" && potionChoisie != "elixir vie"
 	return 0, false, false, used
 }
 
-// Gère la diminution des buffs temporaires après un combat
+// Gère la diminution des buffs temporaires après un combat.
+// Appelée à la fin de chaque combat (victoire, fuite ou défaite): les potions « 3 combats »
+// durent donc bien trois combats au lieu de rester actives indéfiniment.
 func gererBuffsApresCombat(gs *GameState) {
 	if gs.Joueur.BuffCombats > 0 {
 		gs.Joueur.BuffCombats--
@@ -1241,23 +1513,37 @@ func gererBuffsApresCombat(gs *GameState) {
 			}
 		}
 	}
+	if gs.Joueur.ResistanceFeu > 0 {
+		gs.Joueur.ResistanceFeu--
+		if gs.Joueur.ResistanceFeu == 0 {
+			fmt.Printf("🔥 L'effet de la potion de résistance au feu s'estompe...\n")
+		}
+	}
+	if gs.Joueur.Purification > 0 {
+		gs.Joueur.Purification--
+		if gs.Joueur.Purification == 0 {
+			fmt.Printf("🌿 L'effet de la potion de purification s'estompe...\n")
+		}
+	}
+	// La régénération ne dure que pendant les combats
+	gs.Joueur.RegenTours = 0
 }
 
 // Matériaux par tier de donjon
-func getMaterialsForTier(tier int) []string {
+func getMaterialsForTier(tier int) []forgeron.Materiau {
 	switch tier {
 	case 1:
-		return []string{"cuir", "pierre", "bois"}
+		return []forgeron.Materiau{forgeron.Cuir, forgeron.Pierre, forgeron.Bois}
 	case 2:
-		return []string{"cuir renforcé", "fer", "bois dur"}
+		return []forgeron.Materiau{forgeron.CuirRenforce, forgeron.Fer, forgeron.BoisDur}
 	case 3:
-		return []string{"fer renforcé", "pierre précieuse", "os ancien"}
+		return []forgeron.Materiau{forgeron.FerRenforce, forgeron.PierrePrecieuse, forgeron.OsAncien}
 	case 4:
-		return []string{"fer renforcé", "gemme", "écailles"}
+		return []forgeron.Materiau{forgeron.FerRenforce, forgeron.Gemme, forgeron.Ecailles}
 	case 5: // Boss
-		return []string{"gemme de pouvoir", "cristal de mana", "écailles de dragon"}
+		return []forgeron.Materiau{forgeron.GemmeDePouvoir, forgeron.CristalDeMana, forgeron.EcaillesDeDragon}
 	default:
-		return []string{"cuir", "pierre"}
+		return []forgeron.Materiau{forgeron.Cuir, forgeron.Pierre}
 	}
 }
 
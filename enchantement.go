@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	"sloteriaa/struct/forgeron"
+	"sloteriaa/struct/objet"
+)
+
+// Coût d'un nouvel enchantement selon le nombre d'emplacements déjà utilisés
+func coutEnchantement(utilises int) forgeron.Cout {
+	return forgeron.Cout{forgeron.EssenceMagique: 1 + utilises, forgeron.Or: 150 * (utilises + 1)}
+}
+
+// Coût pour relancer un enchantement existant
+func coutRelance() forgeron.Cout {
+	return forgeron.Cout{forgeron.EssenceMagique: 1, forgeron.Or: 100}
+}
+
+// Chance d'échec (%) d'un ajout: chaque emplacement occupé rend l'opération plus risquée
+func chanceEchecEnchantement(utilises int) int {
+	return 20 + 15*utilises
+}
+
+const chanceEchecRelance = 25
+
+// forgeEnchanter: table d'enchantement de la forge
+func forgeEnchanter(gs *GameState) {
+	for {
+		nom, ok := choisirEquipement(gs, "Enchanter quel objet ?")
+		if !ok {
+			return
+		}
+		enchanterObjet(gs, nom)
+	}
+}
+
+// choisirEquipement liste les armes et armures de l'inventaire (équipées comprises)
+func choisirEquipement(gs *GameState, header string) (string, bool) {
+	j := &gs.Joueur
+	noms, counts := compterItems(j)
+	candidats := []string{}
+	opts := []string{}
+	for _, item := range noms {
+		if _, _, ok := cleObjet(item); !ok {
+			continue
+		}
+		suffix := ""
+		if estArmeEquipee(j, item) || estArmureEquipee(j, item) {
+			suffix = "  [Équipé]"
+		}
+		label := libelleExemplaire(j, item)
+		if counts[item] > 1 {
+			label += fmt.Sprintf(" x%d", counts[item])
+		}
+		candidats = append(candidats, item)
+		opts = append(opts, label+suffix)
+	}
+	if len(candidats) == 0 {
+		fmt.Println("Aucune arme ni armure dans l'inventaire.")
+		attendreEntree()
+		return "", false
+	}
+	idx, cancelled := selectWithArrows(header, opts)
+	if cancelled {
+		return "", false
+	}
+	return candidats[idx], true
+}
+
+func enchanterObjet(gs *GameState, nom string) {
+	for {
+		ex, _ := exemplaireDe(&gs.Joueur, nom)
+		utilises := len(ex.Enchantements)
+		if ex.Cle == "" {
+			// Objet de base: pas encore d'exemplaire, rareté commune
+			ex.Rarete = objet.Commun
+		}
+		maxi := objet.MaxEnchantements(ex.Rarete)
+		header := fmt.Sprintf("Table d'enchantement — %s\nEmplacements: %d/%d | Or %d | Essence magique %d",
+			libelleExemplaire(&gs.Joueur, nom), utilises, maxi, gs.Joueur.Argent, gs.Mats[forgeron.EssenceMagique])
+		opts := []string{}
+		actions := []int{}
+		if utilises < maxi {
+			c := coutEnchantement(utilises)
+			opts = append(opts, fmt.Sprintf("Ajouter un enchantement — %d or, Essence x%d (échec %d%%)", c[forgeron.Or], c[forgeron.EssenceMagique], chanceEchecEnchantement(utilises)))
+			actions = append(actions, 0)
+		}
+		for i, e := range ex.Enchantements {
+			c := coutRelance()
+			opts = append(opts, fmt.Sprintf("Relancer « %s » — %d or, Essence x%d (échec %d%%)", e, c[forgeron.Or], c[forgeron.EssenceMagique], chanceEchecRelance))
+			actions = append(actions, i+1)
+		}
+		opts = append(opts, "Retour")
+		actions = append(actions, -1)
+		idx, cancelled := selectWithArrows(header, opts)
+		if cancelled || actions[idx] < 0 {
+			return
+		}
+		if actions[idx] == 0 {
+			nom = ajouterEnchantement(gs, nom, utilises)
+		} else {
+			relancerEnchantement(gs, nom, actions[idx]-1)
+		}
+	}
+}
+
+// ajouterEnchantement débite le coût puis tente d'ajouter un enchantement; retourne le nom (éventuellement devenu unique)
+func ajouterEnchantement(gs *GameState, nom string, utilises int) string {
+	cout := coutEnchantement(utilises)
+	if !ressourcesSuffisantes(gs, cout) {
+		return nom
+	}
+	payerCout(gs, srcEnchantement, nom, cout)
+	if rand.Intn(100) < chanceEchecEnchantement(utilises) {
+		fmt.Println("💨 L'enchantement se dissipe... Les matériaux sont perdus.")
+		attendreEntree()
+		return nom
+	}
+	unique, ok := assurerExemplaire(gs, nom)
+	if !ok {
+		return nom
+	}
+	ex := gs.Joueur.Exemplaires[unique]
+	e := objet.TirerEnchantement(ex.Armure)
+	ex.Enchantements = append(ex.Enchantements, e)
+	gs.Joueur.Exemplaires[unique] = ex
+	fmt.Printf("✨ Enchantement réussi : %s\n", e)
+	attendreEntree()
+	return unique
+}
+
+func relancerEnchantement(gs *GameState, nom string, slot int) {
+	if !ressourcesSuffisantes(gs, coutRelance()) {
+		return
+	}
+	payerCout(gs, srcEnchantement, nom, coutRelance())
+	if rand.Intn(100) < chanceEchecRelance {
+		fmt.Println("💨 La relance échoue, l'enchantement reste inchangé.")
+		attendreEntree()
+		return
+	}
+	ex := gs.Joueur.Exemplaires[nom]
+	e := objet.TirerEnchantement(ex.Armure)
+	ex.Enchantements[slot] = e
+	gs.Joueur.Exemplaires[nom] = ex
+	fmt.Printf("✨ Nouvel enchantement : %s\n", e)
+	attendreEntree()
+}
+
+// enchantementsArme retourne les enchantements de l'arme équipée
+func enchantementsArme(gs *GameState) []objet.Enchantement {
+	if ex, ok := exemplaireDe(&gs.Joueur, gs.Joueur.Attaque); ok {
+		return ex.Enchantements
+	}
+	return nil
+}
+
+// effetsEnchantementsAuToucher applique les enchantements de l'arme après un coup réussi:
+// dégâts élémentaires et statuts. Retourne true si le monstre est étourdi.
+func effetsEnchantementsAuToucher(gs *GameState, mon *Monster) bool {
+	stun := false
+	for _, e := range enchantementsArme(gs) {
+		switch e.Type {
+		case objet.EnchantElementaire:
+			mon.PV -= e.Valeur
+			if mon.PV < 0 {
+				mon.PV = 0
+			}
+			fmt.Printf("✦ Dégâts de %s : %d (PV monstre %d)\n", e.Cible, e.Valeur, mon.PV)
+		case objet.EnchantStatut:
+			if rand.Intn(100) >= e.Valeur {
+				continue
+			}
+			if e.Cible == "stun" {
+				stun = true
+				continue
+			}
+			appliquerStatutMonstre(mon, e.Cible)
+			fmt.Printf("✦ %s est affecté :%s\n", mon.Nom, displayStatusEffects(mon))
+		}
+	}
+	return stun
+}
+
+// appliquerStatutMonstre pose un statut sur le monstre avec la durée des attaques spéciales du joueur
+func appliquerStatutMonstre(mon *Monster, statut string) {
+	duree := 0
+	for _, a := range playerSpecialAttacks {
+		for _, eff := range a.Effects {
+			if eff.Type == statut {
+				duree = eff.Duration
+			}
+		}
+	}
+	switch statut {
+	case "poison":
+		mon.Poisoned, mon.PoisonTurns = true, duree
+	case "burn":
+		mon.Burned, mon.BurnTurns = true, duree
+	case "bleed":
+		mon.Bleeding, mon.BleedTurns = true, duree
+	}
+}
+
+// protectionContre additionne les enchantements de protection des armures portées contre un type de monstre (max 50%)
+func protectionContre(gs *GameState, typeMonstre string) int {
+	total := 0
+	for nom, equipe := range gs.Joueur.ArmuresEquipees {
+		if !equipe {
+			continue
+		}
+		ex, ok := exemplaireDe(&gs.Joueur, nom)
+		if !ok {
+			continue
+		}
+		for _, e := range ex.Enchantements {
+			if e.Type == objet.EnchantProtection && e.Cible == typeMonstre {
+				total += e.Valeur
+			}
+		}
+	}
+	if total > 50 {
+		total = 50
+	}
+	return total
+}
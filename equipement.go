@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"sloteriaa/internal/personnage"
+	"sloteriaa/struct/forgeron"
+	"sloteriaa/struct/objet"
+)
+
+// nouvelExemplaire attribue un identifiant unique à un exemplaire et l'enregistre sous son nom d'inventaire
+func nouvelExemplaire(gs *GameState, ex objet.Exemplaire) string {
+	gs.CompteurExemplaires++
+	ex.ID = gs.CompteurExemplaires
+	if gs.Joueur.Exemplaires == nil {
+		gs.Joueur.Exemplaires = make(map[string]objet.Exemplaire)
+	}
+	nom := ex.Nom()
+	gs.Joueur.Exemplaires[nom] = ex
+	return nom
+}
+
+// exemplaireDe retourne les données uniques d'un objet d'inventaire, s'il en a
+func exemplaireDe(j *personnage.Personnage, nom string) (objet.Exemplaire, bool) {
+	ex, ok := j.Exemplaires[nom]
+	return ex, ok
+}
+
+// cleObjet retrouve la clé CreerArme / CreerArmure d'un nom d'inventaire
+func cleObjet(nom string) (cle string, armure bool, ok bool) {
+	base := objet.NomDeBase(nom)
+	for _, r := range forgeron.RecettesArmesHumaines() {
+		if strings.EqualFold(objet.CreerArme(r.CleArme).Nom, base) {
+			return r.CleArme, false, true
+		}
+	}
+	for _, r := range forgeron.RecettesArmures() {
+		if strings.EqualFold(objet.CreerArmure(r.CleArmure).Nom, base) {
+			return r.CleArmure, true, true
+		}
+	}
+	return "", false, false
+}
+
+// renommerObjet remplace un exemplaire d'inventaire par un nouveau nom en conservant son état équipé
+func renommerObjet(j *personnage.Personnage, ancien, nouveau string) {
+	for i, it := range j.Inventaire {
+		if it == ancien {
+			j.Inventaire[i] = nouveau
+			break
+		}
+	}
+	if j.Attaque == ancien {
+		j.Attaque = nouveau
+	}
+	if j.ArmuresEquipees[ancien] {
+		delete(j.ArmuresEquipees, ancien)
+		j.ArmuresEquipees[nouveau] = true
+	}
+	if ex, ok := j.Exemplaires[ancien]; ok && ancien != nouveau {
+		delete(j.Exemplaires, ancien)
+		j.Exemplaires[nouveau] = ex
+	}
+}
+
+// assurerExemplaire transforme un objet de base en exemplaire unique (rareté commune) pour pouvoir le modifier.
+// Retourne le nom unique de l'objet.
+func assurerExemplaire(gs *GameState, nom string) (string, bool) {
+	if _, ok := exemplaireDe(&gs.Joueur, nom); ok {
+		return nom, true
+	}
+	cle, armure, ok := cleObjet(nom)
+	if !ok {
+		return "", false
+	}
+	nouveau := nouvelExemplaire(gs, objet.Exemplaire{Cle: cle, Armure: armure, Rarete: objet.Commun})
+	renommerObjet(&gs.Joueur, nom, nouveau)
+	return nouveau, true
+}
+
+// armeDe retourne les stats réelles d'une arme d'inventaire (améliorations comprises)
+func armeDe(j *personnage.Personnage, nom string) (objet.Arme, bool) {
+	if ex, ok := exemplaireDe(j, nom); ok && !ex.Armure {
+		return ex.StatsArme(), true
+	}
+	return trouverArmeParNom(nom)
+}
+
+// armureDe retourne les stats réelles d'une armure d'inventaire (améliorations comprises)
+func armureDe(j *personnage.Personnage, nom string) (objet.Armure, bool) {
+	if ex, ok := exemplaireDe(j, nom); ok && ex.Armure {
+		return ex.StatsArmure(), true
+	}
+	return trouverArmureParNom(nom)
+}
+
+// nomsEquipes liste l'arme et les armures actuellement équipées
+func nomsEquipes(j *personnage.Personnage) []string {
+	noms := []string{}
+	if j.Attaque != "" {
+		noms = append(noms, j.Attaque)
+	}
+	for nom, equipe := range j.ArmuresEquipees {
+		if equipe {
+			noms = append(noms, nom)
+		}
+	}
+	return noms
+}
+
+// bonusEquipement additionne un type d'affixe sur tout l'équipement porté
+func bonusEquipement(j *personnage.Personnage, t objet.TypeAffixe) int {
+	total := 0
+	for _, nom := range nomsEquipes(j) {
+		if ex, ok := exemplaireDe(j, nom); ok {
+			total += ex.Bonus(t)
+		}
+	}
+	return total
+}
+
+// libelleExemplaire colore le nom selon la rareté et ajoute les affixes
+func libelleExemplaire(j *personnage.Personnage, nom string) string {
+	ex, ok := exemplaireDe(j, nom)
+	if !ok {
+		return nom
+	}
+	label := objet.Colorer(ex.Rarete, nom)
+	if len(ex.Affixes) > 0 {
+		parts := make([]string, len(ex.Affixes))
+		for i, a := range ex.Affixes {
+			parts[i] = a.String()
+		}
+		label += " {" + strings.Join(parts, ", ") + "}"
+	}
+	if len(ex.Enchantements) > 0 {
+		parts := make([]string, len(ex.Enchantements))
+		for i, e := range ex.Enchantements {
+			parts[i] = e.String()
+		}
+		label += " ✦ " + strings.Join(parts, ", ")
+	}
+	return label
+}
+
+// Prix de vente d'un équipement unique: valeur de l'objet de base, multipliée par la rareté et la qualité
+func prixVenteExemplaire(ex objet.Exemplaire) int {
+	base := ex.StatsArme().Valeur
+	if ex.Armure {
+		base = ex.StatsArmure().Valeur
+	}
+	return base * objet.MultiplicateurVente(ex.Rarete) * (100 + objet.PourcentageQualite(ex.Qualite)) / 100
+}
+
+// dropEquipement tente de faire tomber un équipement de la table du tier, avec rareté et affixes
+func dropEquipement(gs *GameState, tier int) {
+	if tier > 4 {
+		tier = 4
+	}
+	drops, exists := dungeonDrops[tier]
+	if !exists {
+		return
+	}
+	for _, d := range drops.Items {
+		if rand.Intn(100) >= d.Chance {
+			continue
+		}
+		ex := objet.Exemplaire{Cle: d.ItemName, Armure: isArmor(d.ItemName), Rarete: objet.TirerRarete(tier)}
+		if !isWeapon(d.ItemName) && !ex.Armure {
+			continue
+		}
+		ex.Affixes = objet.TirerAffixes(ex.Rarete, tier)
+		nom := ex.NomBase()
+		if ex.Rarete != objet.Commun {
+			nom = nouvelExemplaire(gs, ex)
+		}
+		if !acquerirObjet(gs, nom) {
+			delete(gs.Joueur.Exemplaires, nom)
+			return
+		}
+		fmt.Printf("🎁 Équipement trouvé : %s\n", libelleExemplaire(&gs.Joueur, nom))
+		return
+	}
+}
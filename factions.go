@@ -0,0 +1,151 @@
+package main
+
+import "fmt"
+
+// Faction de la ville: la réputation s'y gagne par les ventes, la forge, les monstres tués et les quêtes
+type Faction struct {
+	Cle         string
+	Nom         string
+	Description string
+}
+
+const (
+	factionGuilde    = "guilde"
+	factionForgerons = "forgerons"
+	factionGarde     = "garde"
+)
+
+var factions = []Faction{
+	{factionGuilde, "Guilde des marchands", "commerce: remises chez tous les marchands"},
+	{factionForgerons, "Confrérie des forgerons", "forge: recettes réservées aux membres"},
+	{factionGarde, "Garde de Sloteria", "monstres tués: la garde vous ouvre ses portes"},
+}
+
+// Rangs de réputation, du plus bas au plus haut
+const (
+	rangHostile = iota
+	rangMefiant
+	rangNeutre
+	rangApprecie
+	rangHonore
+	rangRevere
+)
+
+// Seuil de points pour atteindre chaque rang (Hostile: en dessous de Méfiant)
+var paliersFaction = []struct {
+	Seuil int
+	Titre string
+}{
+	{0, "Hostile"},
+	{-50, "Méfiant"},
+	{0, "Neutre"},
+	{100, "Apprécié"},
+	{300, "Honoré"},
+	{700, "Révéré"},
+}
+
+// Remise de la guilde par rang (négative: majoration)
+var remisesGuilde = []int{-10, -5, 0, 3, 6, 10}
+
+// Recettes de forge offertes par une faction à un rang donné (palier forgeron.TierReserve:
+// introuvables autrement)
+var recettesFaction = []struct {
+	Faction string
+	Rang    int
+	Cle     string
+}{
+	{factionForgerons, rangHonore, "HacheDeLaConfrerie"},
+	{factionForgerons, rangHonore, "PlastronDeLaConfrerie"},
+	{factionForgerons, rangRevere, "EpeeDuMaitreForgeron"},
+}
+
+// Gains de réputation
+const (
+	orParPointGuilde  = 20 // or échangé pour un point auprès de la guilde
+	repParObjetForge  = 2  // par équipement forgé
+	repParTierMonstre = 1  // par tier du monstre vaincu
+)
+
+func factionParCle(cle string) (Faction, bool) {
+	for _, f := range factions {
+		if f.Cle == cle {
+			return f, true
+		}
+	}
+	return Faction{}, false
+}
+
+func rangDePoints(points int) int {
+	if points < paliersFaction[rangMefiant].Seuil {
+		return rangHostile
+	}
+	rang := rangMefiant
+	for r := rangNeutre; r < len(paliersFaction); r++ {
+		if points >= paliersFaction[r].Seuil {
+			rang = r
+		}
+	}
+	return rang
+}
+
+// rangFaction retourne le rang du joueur auprès d'une faction
+func rangFaction(gs *GameState, cle string) int {
+	return rangDePoints(gs.Reputation[cle])
+}
+
+// reputationAuMoins indique si le joueur a atteint le rang demandé (conditions de dialogue, de quête...)
+func reputationAuMoins(gs *GameState, cle string, rang int) bool {
+	return rangFaction(gs, cle) >= rang
+}
+
+// modifierReputation ajoute (ou retire) des points auprès d'une faction, annonce les changements de rang
+// et accorde les récompenses du nouveau rang
+func modifierReputation(gs *GameState, cle string, delta int) {
+	f, ok := factionParCle(cle)
+	if !ok || delta == 0 {
+		return
+	}
+	if gs.Reputation == nil {
+		gs.Reputation = make(map[string]int)
+	}
+	avant := rangFaction(gs, cle)
+	gs.Reputation[cle] += delta
+	apres := rangFaction(gs, cle)
+	if apres == avant {
+		return
+	}
+	if apres > avant {
+		fmt.Printf("🏛️ %s: vous êtes désormais %s.\n", f.Nom, paliersFaction[apres].Titre)
+	} else {
+		fmt.Printf("🏛️ %s: votre réputation tombe à %s.\n", f.Nom, paliersFaction[apres].Titre)
+	}
+	for _, r := range recettesFaction {
+		if r.Faction == cle && r.Rang <= apres {
+			debloquerRecette(gs, r.Cle, f.Nom)
+		}
+	}
+}
+
+// rangRecetteFaction retourne le titre du rang qui accorde une recette de faction
+func rangRecetteFaction(cle string) string {
+	for _, r := range recettesFaction {
+		if r.Cle == cle {
+			return paliersFaction[r.Rang].Titre
+		}
+	}
+	return ""
+}
+
+// remiseGuilde retourne la remise (ou majoration) accordée par la guilde des marchands
+func remiseGuilde(gs *GameState) int {
+	return remisesGuilde[rangFaction(gs, factionGuilde)]
+}
+
+// afficherReputation liste le rang et les points auprès de chaque faction
+func afficherReputation(gs *GameState) {
+	fmt.Println()
+	fmt.Println("Réputation")
+	for _, f := range factions {
+		fmt.Printf("  %-26s %-9s (%d pts) — %s\n", f.Nom, paliersFaction[rangFaction(gs, f.Cle)].Titre, gs.Reputation[f.Cle], f.Description)
+	}
+}
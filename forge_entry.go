@@ -1,6 +1,97 @@
 package main
 
+import (
+	"fmt"
+
+	"sloteriaa/struct/forgeron"
+	"sloteriaa/struct/objet"
+)
+
 func EnterForge(gs *GameState) {
 	// Version simple, similaire au marché
 	EnterForgeSimple(gs)
 }
+
+// atelierJeu branche l'interface de forge visuelle sur l'état réel de la partie
+type atelierJeu struct {
+	gs       *GameState
+	variante forgeron.VarianteRecette // variante choisie en entrant dans l'atelier
+	annonces []string                 // messages de progression à afficher en quittant l'interface
+	forges   []string                 // clés des objets forgés, dont les suites (temps, réputation, quêtes) sont appliquées en quittant
+}
+
+func (a *atelierJeu) Materiaux() forgeron.InventaireMateriaux { return a.gs.Mats }
+func (a *atelierJeu) OrDisponible() int                       { return a.gs.Joueur.Argent }
+func (a *atelierJeu) PoidsActuel() int                        { return PoidsTotal(&a.gs.Joueur) }
+func (a *atelierJeu) PoidsMax() int                           { return PoidsMaxInventaire }
+
+func (a *atelierJeu) RecetteConnue(cle string, tier int) bool {
+	return recetteConnue(a.gs, cle, tier)
+}
+
+func (a *atelierJeu) CoutRecette(c forgeron.Cout) forgeron.Cout {
+	return a.variante.Appliquer(c)
+}
+
+func (a *atelierJeu) ArmeEquipee() (objet.Arme, bool) {
+	if a.gs.Joueur.Attaque == "" {
+		return objet.Arme{}, false
+	}
+	return armeDe(&a.gs.Joueur, a.gs.Joueur.Attaque)
+}
+
+func (a *atelierJeu) ArmureEquipee(typ objet.TypeArmure) (objet.Armure, bool) {
+	for nom, equipe := range a.gs.Joueur.ArmuresEquipees {
+		if ar, ok := armureDe(&a.gs.Joueur, nom); equipe && ok && ar.Type == typ {
+			return ar, true
+		}
+	}
+	return objet.Armure{}, false
+}
+
+// Fabriquer passe par forgerObjet, comme la forge en menus; l'interface ne peut pas ouvrir
+// le choix jeter/coffre, un objet trop lourd est donc refusé avant paiement
+func (a *atelierJeu) Fabriquer(cle string, armure bool, cout forgeron.Cout) (string, error) {
+	if !forgeron.PeutPayer(a, cout) {
+		return "", forgeron.ErrRessources
+	}
+	c := Commande{Cle: cle, Armure: armure, Variante: a.variante.Nom}
+	if PoidsTotal(&a.gs.Joueur)+PoidsObjet(nomCommande(c)) > PoidsMaxInventaire {
+		return "", forgeron.ErrTropLourd
+	}
+	nom, annonces := forgerObjet(a.gs, c, cout)
+	a.annonces = append(a.annonces, annonces...)
+	a.forges = append(a.forges, cle)
+	return nom, nil
+}
+
+// EnterForgeVisuelle ouvre l'interface à deux panneaux (tri, filtre, comparaison) sur la partie en cours
+func EnterForgeVisuelle(gs *GameState) {
+	v, ok := choisirVarianteAtelier(gs)
+	if !ok {
+		return
+	}
+	a := &atelierJeu{gs: gs, variante: v}
+	forgeron.RunForgeInteractive(a)
+	// L'interface quitte l'écran alternatif: on y revient pour le reste du jeu
+	enterAltScreen()
+	if len(a.forges) == 0 {
+		return
+	}
+	for _, msg := range a.annonces {
+		fmt.Println(msg)
+	}
+	// Suites regroupées par recette, dans l'ordre des fabrications
+	ordre := []string{}
+	nombres := map[string]int{}
+	for _, cle := range a.forges {
+		if nombres[cle] == 0 {
+			ordre = append(ordre, cle)
+		}
+		nombres[cle]++
+	}
+	for _, cle := range ordre {
+		apresForge(gs, cle, nombres[cle])
+	}
+	attendreEntree()
+}
@@ -10,9 +10,10 @@ import (
 
 func EnterForgeSimple(gs *GameState) {
 	for {
-		header := fmt.Sprintf("Forge — Or %d", gs.Joueur.Argent)
-		idx, cancelled := selectWithArrows(header, []string{"Forger une arme", "Forger une armure", "Sortir de la forge"})
-		if cancelled || idx == 2 {
+		header := fmt.Sprintf("Forge — Or %d — Compétence niv. %d (%d/%d XP)\nMatériaux: %s", gs.Joueur.Argent,
+			gs.Joueur.CompetenceForge, gs.Joueur.XPForge, xpForgeRequise(gs.Joueur.CompetenceForge), formatMaterials(forgeron.Cout(gs.Mats)))
+		idx, cancelled := selectWithArrows(header, []string{"Forger une arme", "Forger une armure", "Atelier visuel (tri, comparaison)", "Table d'enchantement", "Améliorer un équipement", "Démanteler", "Raffiner des matériaux", "Livre de recettes", commandesLabel(gs), "Sortir de la forge"})
+		if cancelled || idx == 9 {
 			return
 		}
 		switch idx {
@@ -20,12 +21,31 @@ func EnterForgeSimple(gs *GameState) {
 			forgeSelectWeapon(gs)
 		case 1:
 			forgeSelectArmor(gs)
+		case 2:
+			EnterForgeVisuelle(gs)
+		case 3:
+			forgeEnchanter(gs)
+		case 4:
+			forgeAmeliorer(gs)
+		case 5:
+			forgeDemanteler(gs)
+		case 6:
+			forgeRaffiner(gs)
+		case 7:
+			EnterLivreRecettes(gs)
+		case 8:
+			EnterCommandes(gs)
 		}
 	}
 }
 
 func forgeSelectWeapon(gs *GameState) {
-	recs := forgeron.RecettesArmesHumaines()
+	recs := []forgeron.Recette{}
+	for _, r := range forgeron.RecettesArmesHumaines() {
+		if recetteConnue(gs, r.CleArme, r.Tier) {
+			recs = append(recs, r)
+		}
+	}
 	if len(recs) == 0 {
 		fmt.Println("Aucune recette.")
 		attendreEntree()
@@ -49,18 +69,18 @@ func forgeSelectWeapon(gs *GameState) {
 			return
 		}
 		r := recs[sel]
-		craftWithCost(gs, r.Cout, func() {
-			a := objet.CreerArme(r.CleArme)
-			gs.Joueur.Inventaire = append(gs.Joueur.Inventaire, a.Nom)
-			fmt.Printf("Forgé: %s (ATK %d, Poids %d) — Ajouté à l'inventaire\n", a.Nom, a.EffetAttaque, a.Poids)
-			attendreEntree()
-		})
+		fabriquerRecette(gs, r.CleArme, false, r.Cout)
 		// boucle: rester dans la liste des armes après craft
 	}
 }
 
 func forgeSelectArmor(gs *GameState) {
-	recs := forgeron.RecettesArmures()
+	recs := []forgeron.RecetteArmure{}
+	for _, r := range forgeron.RecettesArmures() {
+		if recetteConnue(gs, r.CleArmure, r.Tier) {
+			recs = append(recs, r)
+		}
+	}
 	if len(recs) == 0 {
 		fmt.Println("Aucune recette.")
 		attendreEntree()
@@ -86,34 +106,26 @@ func forgeSelectArmor(gs *GameState) {
 			return
 		}
 		r := recs[sel]
-		craftWithCost(gs, r.Cout, func() {
-			ar := objet.CreerArmure(r.CleArmure)
-			gs.Joueur.Inventaire = append(gs.Joueur.Inventaire, ar.Nom)
-			fmt.Printf("Forgé: %s (DEF %d, Poids %d) — Ajouté à l'inventaire\n", ar.Nom, ar.EffetDefense, ar.Poids)
-			attendreEntree()
-		})
+		fabriquerRecette(gs, r.CleArmure, true, r.Cout)
 		// boucle: rester dans la liste des armures après craft
 	}
 }
 
-// Helper: check mats + gold, debit, then run success action
-func craftWithCost(gs *GameState, cout forgeron.Cout, onSuccess func()) {
-	// Convert mats to forgeron inventory for checking and debiting
-	inv := matsToForgeron(gs.Mats)
-	// Build materials list and gold cost
+// ressourcesSuffisantes vérifie l'or et les matériaux d'un coût et affiche ce qui manque.
+// Tous les ateliers l'appellent avant payerCout.
+func ressourcesSuffisantes(gs *GameState, cout forgeron.Cout) bool {
+	// Build materials list and gold cost (gold lives in Joueur.Argent, not in the ledger)
 	mats := []string{}
-	gold := 0
-	for _, m := range []forgeron.Materiau{forgeron.Fer, forgeron.Bois, forgeron.Cuir, forgeron.EssenceMagique, forgeron.Or} {
-		if q, ok := cout[m]; ok {
-			if m == forgeron.Or {
-				gold = q
-				continue
-			}
+	gold := cout[forgeron.Or]
+	matCout := forgeron.Cout{}
+	for _, m := range forgeron.OrdreMateriaux {
+		if q, ok := cout[m]; ok && m != forgeron.Or {
+			matCout[m] = q
 			mats = append(mats, fmt.Sprintf("%s x%d", m, q))
 		}
 	}
 	// Check materials availability
-	hasMats := inv.AAssez(cout)
+	hasMats := gs.Mats.AAssez(matCout)
 	hasGold := gs.Joueur.Argent >= gold
 	if !hasMats || !hasGold {
 		fmt.Println("Ressources insuffisantes.")
@@ -125,22 +137,16 @@ func craftWithCost(gs *GameState, cout forgeron.Cout, onSuccess func()) {
 		}
 		fmt.Printf("  Or: %d\n", gold)
 		attendreEntree()
-		return
+		return false
 	}
-	// Debit
-	inv.Debiter(cout)
-	gs.Mats = forgeronToMats(inv)
-	gs.Joueur.Argent -= gold
-	// Success
-	onSuccess()
+	return true
 }
 
 // formatMaterials returns a human string for non-gold material costs
 func formatMaterials(cout forgeron.Cout) string {
 	parts := []string{}
-	order := []forgeron.Materiau{forgeron.Fer, forgeron.Bois, forgeron.Cuir, forgeron.EssenceMagique}
-	for _, m := range order {
-		if q, ok := cout[m]; ok && q > 0 {
+	for _, m := range forgeron.OrdreMateriaux {
+		if q, ok := cout[m]; ok && q > 0 && m != forgeron.Or {
 			parts = append(parts, fmt.Sprintf("%s x%d", m, q))
 		}
 	}
@@ -149,3 +155,18 @@ func formatMaterials(cout forgeron.Cout) string {
 	}
 	return strings.Join(parts, ", ")
 }
+
+// coutRecette retrouve le coût de fabrication d'une arme ou d'une armure par sa clé
+func coutRecette(cle string) (forgeron.Cout, bool) {
+	for _, r := range forgeron.RecettesArmesHumaines() {
+		if r.CleArme == cle {
+			return r.Cout, true
+		}
+	}
+	for _, r := range forgeron.RecettesArmures() {
+		if r.CleArmure == cle {
+			return r.Cout, true
+		}
+	}
+	return nil, false
+}
@@ -11,9 +11,41 @@ import (
 
 type GameState struct {
 	Joueur personnage.Personnage
-	Mats   map[string]int
+	Mats   forgeron.InventaireMateriaux // Registre unique des matériaux (l'or est dans Joueur.Argent)
 	XP     int
 	Level  int
+	Coffre Coffre
+	// Compteur des identifiants d'exemplaires uniques (équipements rares)
+	CompteurExemplaires int
+	// Recettes débloquées (clé de recette -> true), en plus de celles connues dès le départ
+	RecettesDebloquees map[string]bool
+	// File des commandes passées au forgeron
+	Commandes []Commande
+	// Cours du marché par article (prix dynamiques)
+	Marche map[string]Cours
+	// Stock de chaque marchand (clé du marchand) et rotation des articles rares
+	Stocks map[string]*StockMarchand
+	// Or échangé avec chaque marchand, qui détermine la réputation
+	ReputationMarchands map[string]int
+	// Réputation auprès des factions de la ville (clé de faction -> points)
+	Reputation map[string]int
+	// Lieu de la carte où se trouve le joueur (vide: lieu de départ)
+	Lieu string
+	// Heures de jeu écoulées depuis le début de la partie
+	Heures int
+	// Heures passées à flâner au marché le jour JourFlanerie (plafonnées par jour)
+	HeuresFlanees int
+	JourFlanerie  int
+	// Quêtes acceptées (clé de quête -> progression)
+	Quetes map[string]*EtatQuete
+	// Souvenirs des conversations avec les PNJ (cadeaux faits, choix marquants)
+	Marques map[string]bool
+	// Journal des derniers mouvements d'or et de matériaux
+	Journal []EcritureJournal
+	// Or gagné et dépensé par source depuis le début de la partie
+	BilanOr map[string]BilanSource
+	// Objets vendus pendant la session, rachetables (non sauvegardés)
+	Rachats []objetVendu `json:"-"`
 }
 
 func StartGameNew() {
@@ -48,30 +80,35 @@ func StartGameNew() {
 
 	gs := GameState{
 		Joueur: p,
-		Mats: map[string]int{
-			"Or":                            10000,
-			string(forgeron.Fer):            8,
-			string(forgeron.Bois):           6,
-			string(forgeron.Cuir):           4,
-			string(forgeron.EssenceMagique): 2,
+		Mats: forgeron.InventaireMateriaux{
+			forgeron.Fer:            8,
+			forgeron.Bois:           6,
+			forgeron.Cuir:           4,
+			forgeron.EssenceMagique: 2,
 		},
-		XP:    0,
-		Level: 1,
+		XP:                 0,
+		Level:              1,
+		RecettesDebloquees: map[string]bool{},
+		Heures:             heureDepart,
 	}
 	if isAdmin {
 		gs.Level = 20
 		gs.XP = 0
+		for _, e := range toutesLesRecettes() {
+			gs.RecettesDebloquees[e.Cle] = true
+		}
 	}
-	// Boost materials/gold further for admin
+	// Boost materials further for admin
 	if isAdmin {
-		gs.Mats["Or"] = 9999999
-		gs.Mats[string(forgeron.Fer)] = 9999
-		gs.Mats[string(forgeron.Bois)] = 9999
-		gs.Mats[string(forgeron.Cuir)] = 9999
-		gs.Mats[string(forgeron.EssenceMagique)] = 9999
+		for _, m := range forgeron.OrdreMateriaux {
+			if m != forgeron.Or {
+				gs.Mats[m] = 9999
+			}
+		}
 	}
 
 	showIntroLore(&gs)
+	accepterQuetesPrincipales(&gs)
 	if !isAdmin {
 		giveStartingEquipment(&gs)
 	}
@@ -93,23 +130,6 @@ func StartGameFromSave(gs *GameState) {
 	worldLoop(gs)
 }
 
-// --- Conversion helpers for materials ---
-func matsToForgeron(inv map[string]int) forgeron.InventaireMateriaux {
-	res := make(forgeron.InventaireMateriaux)
-	for k, v := range inv {
-		res[forgeron.Materiau(k)] = v
-	}
-	return res
-}
-
-func forgeronToMats(inv forgeron.InventaireMateriaux) map[string]int {
-	res := make(map[string]int)
-	for k, v := range inv {
-		res[string(k)] = v
-	}
-	return res
-}
-
 func showIntroLore(gs *GameState) {
 	// Cinématique d'intro par scènes, validation manuelle
 	scenes := [][]string{
@@ -151,44 +171,3 @@ func giveStartingEquipment(gs *GameState) {
 	// L'équipement de départ est déjà géré dans personnage.go
 	// Pas besoin d'ajouter d'arme ici pour éviter la duplication
 }
-
-func worldLoop(gs *GameState) {
-	for {
-		header := fmt.Sprintf("Ville de Sloteria — Niveau %d (XP %d) — Or %d", gs.Level, gs.XP, gs.Joueur.Argent)
-		opts := []string{"Aller à la Forge", "Aller au Marché", "Entrer dans le Donjon", "Inventaire", "Stats du personnage", "Sauvegarder", "Quitter le jeu"}
-		choice, cancelled := selectWithArrows(header, opts)
-		if cancelled {
-			showCursor() // Réaffiche le curseur avant de quitter
-			fmt.Println("À bientôt !")
-			return
-		}
-		switch choice {
-		case 0:
-			EnterForge(gs)
-		case 1:
-			EnterShop(gs)
-		case 2:
-			EnterDungeon(gs)
-		case 3:
-			afficherInventaireInteractif(&gs.Joueur)
-		case 4:
-			clearScreen()
-			personnage.AfficherInfos(gs.Joueur)
-			attendreEntree()
-			clearScreen()
-		case 5:
-			if err := SaveGame(gs); err != nil {
-				fmt.Printf("Erreur de sauvegarde: %s\n", err)
-			} else {
-				fmt.Println("Sauvegarde effectuée.")
-			}
-			attendreEntree()
-		case 6:
-			showCursor() // Réaffiche le curseur avant de quitter
-			fmt.Println("À bientôt !")
-			return
-		default:
-			fmt.Println("Choix invalide.")
-		}
-	}
-}
@@ -34,12 +34,23 @@ type Personnage struct {
 	Agilite         int
 	Endurance       int
 	ArmuresEquipees map[string]bool
-	Materiaux       map[string]int // Matériaux de craft
+	Exemplaires     map[string]objet.Exemplaire // Équipements uniques (rareté, affixes) par nom d'inventaire
+	Materiaux       map[string]int              `json:",omitempty"` // Obsolète: fusionné dans GameState.Mats au chargement
 	// Buffs temporaires
 	BuffForce     int // Bonus temporaire de Force
 	BuffAgilite   int // Bonus temporaire d'Agilité
 	BuffEndurance int // Bonus temporaire d'Endurance
 	BuffCombats   int // Nombre de combats restants pour les buffs
+	// Statuts négatifs (poison, burn, bleed) et tours restants, conservés entre les combats
+	Statuts map[string]int
+	// Effets d'alchimie
+	ResistanceFeu int // Combats restants avec résistance au feu
+	Purification  int // Combats restants d'immunité aux statuts
+	RegenTours    int // Tours de combat restants de régénération
+	RegenPV       int // PV rendus par tour de régénération
+	// Compétence de forge: progresse à chaque fabrication
+	CompetenceForge int
+	XPForge         int
 }
 
 // ----------------- Initialisation -----------------
@@ -73,7 +84,6 @@ func initPersonnage(nom, classe string, niveau, pvmax, pvactuels, argent int, in
 		Agilite:         5, // sera écrasé par les stats de classe
 		Endurance:       5, // sera écrasé par les stats de classe
 		ArmuresEquipees: make(map[string]bool),
-		Materiaux:       make(map[string]int),
 	}
 }
 
@@ -311,7 +321,9 @@ func AfficherInfos(p Personnage) {
 	// Weapon attack and total damage
 	wepAtk := 0
 	if p.Attaque != "" {
-		if a, ok := findWeaponByNameOrKey(p.Attaque); ok {
+		if ex, ok := p.Exemplaires[p.Attaque]; ok && !ex.Armure {
+			wepAtk = ex.StatsArme().EffetAttaque
+		} else if a, ok := findWeaponByNameOrKey(p.Attaque); ok {
 			wepAtk = a.EffetAttaque
 		}
 	}
@@ -321,6 +333,7 @@ func AfficherInfos(p Personnage) {
 	defTotal := computeDefense(p)
 	line("Défense", fmt.Sprintf("%d", defTotal))
 	line("Argent", fmt.Sprintf("%d pièces", p.Argent))
+	line("Forge", fmt.Sprintf("niv. %d (%d XP)", p.CompetenceForge, p.XPForge))
 
 	fmt.Println(bot)
 }
@@ -346,11 +359,11 @@ func padVisible(s string, width int) string {
 // --- Helpers to resolve equipped items to stats ---
 func findWeaponByNameOrKey(n string) (objet.Arme, bool) {
 	keys := []string{
-		"EpeeRouillee", "EpeeFer", "EpeeMagique", "EpeeCourte",
-		"Hache", "HacheDeCombat", "HacheDeBataille",
+		"EpeeRouillee", "EpeeFer", "EpeeMagique", "EpeeCourte", "EpeeDuMaitreForgeron",
+		"Hache", "HacheDeCombat", "HacheDeBataille", "HacheDeLaConfrerie",
 		"ArcBois", "ArcLong", "ArcElfe",
 	}
-	needle := strings.ToLower(strings.TrimSpace(n))
+	needle := strings.ToLower(strings.TrimSpace(objet.NomDeBase(n)))
 	for _, k := range keys {
 		a := objet.CreerArme(k)
 		if strings.EqualFold(needle, k) || strings.EqualFold(needle, a.Nom) {
@@ -363,11 +376,11 @@ func findWeaponByNameOrKey(n string) (objet.Arme, bool) {
 func findArmorByDisplayOrKey(n string) (objet.Armure, bool) {
 	keys := []string{
 		"CasqueCuir", "CasqueCuirRenforce", "CasqueFer", "CasqueFerRenforce",
-		"PlastronCuir", "PlastronCuirRenforce", "PlastronFer", "PlastronFerRenforce",
+		"PlastronCuir", "PlastronCuirRenforce", "PlastronFer", "PlastronFerRenforce", "PlastronDeLaConfrerie",
 		"PantalonCuir", "PantalonCuirRenforce", "PantalonFer", "PantalonFerRenforce",
 		"BottesCuir", "BottesCuirRenforce", "BottesFer", "BottesFerRenforce",
 	}
-	needle := strings.ToLower(strings.TrimSpace(n))
+	needle := strings.ToLower(strings.TrimSpace(objet.NomDeBase(n)))
 	for _, k := range keys {
 		ar := objet.CreerArmure(k)
 		if strings.EqualFold(needle, k) || strings.EqualFold(needle, ar.Nom) {
@@ -386,7 +399,9 @@ func computeDefense(p Personnage) int {
 		if !equipped {
 			continue
 		}
-		if ar, ok := findArmorByDisplayOrKey(name); ok {
+		if ex, ok := p.Exemplaires[name]; ok && ex.Armure {
+			total += ex.StatsArmure().EffetDefense
+		} else if ar, ok := findArmorByDisplayOrKey(name); ok {
 			total += ar.EffetDefense
 		}
 	}
@@ -12,16 +12,29 @@ import (
 // Limite de poids totale autorisée dans l'inventaire
 const PoidsMaxInventaire = 50
 
+// Au-delà de ce poids, le joueur est surchargé (malus d'agilité et d'esquive)
+const SeuilSurcharge = 40
+
 // poidsConnus mappe les objets connus à leur poids (par défaut 1 si inconnu)
 var poidsConnus = map[string]int{
 	"potion": 1,
 }
 
-// PoidsObjet retourne le poids d'un objet (insensible à la casse)
+// PoidsObjet retourne le poids d'un objet (insensible à la casse).
+// Les armes, armures et butins de monstres utilisent leur poids réel.
 func PoidsObjet(nom string) int {
 	if p, ok := poidsConnus[strings.ToLower(nom)]; ok {
 		return p
 	}
+	if arme, ok := trouverArmeParNom(nom); ok {
+		return arme.Poids
+	}
+	if armure, ok := trouverArmureParNom(nom); ok {
+		return armure.Poids
+	}
+	if butin, ok := trouverArmeMonstreParNom(nom); ok {
+		return butin.Poids
+	}
 	return 1
 }
 
@@ -56,9 +69,27 @@ func PoidsTotal(j *personnage.Personnage) int {
 	return total
 }
 
+// malusSurcharge retourne le malus d'agilité lié au poids porté (-1 tous les 2 points au-delà du seuil)
+func malusSurcharge(j *personnage.Personnage) int {
+	exces := PoidsTotal(j) - SeuilSurcharge
+	if exces <= 0 {
+		return 0
+	}
+	return (exces + 1) / 2
+}
+
+// ligneOrEtPoids résume l'or et la charge portée pour les en-têtes d'inventaire
+func ligneOrEtPoids(j *personnage.Personnage) string {
+	ligne := fmt.Sprintf("Or: %d | Poids: %d/%d", j.Argent, PoidsTotal(j), PoidsMaxInventaire)
+	if m := malusSurcharge(j); m > 0 {
+		ligne += fmt.Sprintf(" (surchargé: Agilité -%d)", m)
+	}
+	return ligne
+}
+
 func afficherInventaire(j *personnage.Personnage) {
 	fmt.Println("🧳 Inventaire :")
-	fmt.Printf("Or: %d\n", j.Argent)
+	fmt.Println(ligneOrEtPoids(j))
 	if estInventaireVide(j) {
 		fmt.Println("Votre inventaire est vide.")
 		return
@@ -103,7 +134,7 @@ func afficherInventaire(j *personnage.Personnage) {
 			if estArmeEquipee(j, item) || estArmureEquipee(j, item) {
 				suffix = "  [Équipé]"
 			}
-			label := item
+			label := libelleExemplaire(j, item)
 
 			// Afficher les descriptions des potions
 			switch strings.ToLower(item) {
@@ -121,15 +152,21 @@ func afficherInventaire(j *personnage.Personnage) {
 				label += " (Guérit statuts)"
 			case "elixir vie":
 				label += " (+100 PV)"
+			case "potion resistance feu":
+				label += " (Immunité brûlure, 3 combats)"
+			case "potion purification":
+				label += " (Guérit et immunise, 2 combats)"
+			case "potion regeneration":
+				label += " (+8 PV/tour, 10 tours)"
 			}
 
 			// Afficher les stats des armes
-			if arme, ok := trouverArmeParNom(item); ok && arme.Nom != "" {
+			if arme, ok := armeDe(j, item); ok && arme.Nom != "" {
 				label += fmt.Sprintf(" (ATK %d)", arme.EffetAttaque)
 			}
 
 			// Afficher les stats des armures
-			if armure, ok := trouverArmureParNom(item); ok && armure.Nom != "" {
+			if armure, ok := armureDe(j, item); ok && armure.Nom != "" {
 				label += fmt.Sprintf(" (DEF %d)", armure.EffetDefense)
 			}
 
@@ -167,7 +204,7 @@ func afficherInventaireInteractif(j *personnage.Personnage) {
 	for {
 		// Render gold and list (grouped) with cursor
 		noms, counts := compterItems(j)
-		fmt.Printf("Or: %d\n", j.Argent)
+		fmt.Println(ligneOrEtPoids(j))
 		for i, item := range noms {
 			prefix := "  "
 			if i == index {
@@ -177,7 +214,7 @@ func afficherInventaireInteractif(j *personnage.Personnage) {
 			if estArmeEquipee(j, item) || estArmureEquipee(j, item) {
 				suffix = "  [Équipé]"
 			}
-			label := item
+			label := libelleExemplaire(j, item)
 			// Afficher les descriptions des potions
 			switch strings.ToLower(item) {
 			case "potion":
@@ -194,15 +231,21 @@ func afficherInventaireInteractif(j *personnage.Personnage) {
 				label += " (Guérit statuts)"
 			case "elixir vie":
 				label += " (+100 PV)"
+			case "potion resistance feu":
+				label += " (Immunité brûlure, 3 combats)"
+			case "potion purification":
+				label += " (Guérit et immunise, 2 combats)"
+			case "potion regeneration":
+				label += " (+8 PV/tour, 10 tours)"
 			}
 
 			// Afficher les stats des armes
-			if arme, ok := trouverArmeParNom(item); ok && arme.Nom != "" {
+			if arme, ok := armeDe(j, item); ok && arme.Nom != "" {
 				label += fmt.Sprintf(" (ATK %d)", arme.EffetAttaque)
 			}
 
 			// Afficher les stats des armures
-			if armure, ok := trouverArmureParNom(item); ok && armure.Nom != "" {
+			if armure, ok := armureDe(j, item); ok && armure.Nom != "" {
 				label += fmt.Sprintf(" (DEF %d)", armure.EffetDefense)
 			}
 
@@ -432,15 +475,84 @@ func retirerObjet(j *personnage.Personnage, index int) {
 	j.Inventaire = append(j.Inventaire[:index], j.Inventaire[index+1:]...)
 }
 
-// ajouterObjet ajoute un objet à l'inventaire
-func ajouterObjet(j *personnage.Personnage, objet string) bool {
-	poidsActuel := PoidsTotal(j)
-	poidsAjout := PoidsObjet(objet)
-	if poidsActuel+poidsAjout > PoidsMaxInventaire {
-		fmt.Printf("❌ Trop lourd: %s (poids %d). Poids actuel %d/%d.\n", objet, poidsAjout, poidsActuel, PoidsMaxInventaire)
+// faireDeLaPlace vérifie qu'un objet peut entrer dans l'inventaire.
+// Si c'est trop lourd, le joueur peut jeter des objets ou les envoyer au coffre
+// jusqu'à libérer assez de poids, ou renoncer.
+func faireDeLaPlace(gs *GameState, nom string) bool {
+	return faireDeLaPlacePour(gs, nom, PoidsObjet(nom))
+}
+
+// faireDeLaPlacePour libère le poids d'un lot entier (nom affiché, poids total) avant de le produire
+func faireDeLaPlacePour(gs *GameState, nom string, poidsAjout int) bool {
+	for {
+		poidsActuel := PoidsTotal(&gs.Joueur)
+		if poidsActuel+poidsAjout <= PoidsMaxInventaire {
+			return true
+		}
+		header := fmt.Sprintf("Inventaire plein: %s (poids %d) — Poids actuel %d/%d", nom, poidsAjout, poidsActuel, PoidsMaxInventaire)
+		idx, cancelled := selectWithArrows(header, []string{"Jeter un objet", "Envoyer un objet au coffre", fmt.Sprintf("Laisser %s", nom)})
+		libere := false
+		switch {
+		case cancelled || idx == 2:
+		case idx == 0:
+			libere = jeterObjet(gs)
+		case idx == 1:
+			libere = deposerObjetCoffre(gs)
+		}
+		if !libere {
+			fmt.Printf("Vous laissez %s.\n", nom)
+			return false
+		}
+	}
+}
+
+// acquerirObjet est le point d'entrée pour ajouter un objet à l'inventaire (achat, butin, alchimie):
+// il fait la place puis range l'objet
+func acquerirObjet(gs *GameState, nom string) bool {
+	if !faireDeLaPlace(gs, nom) {
+		return false
+	}
+	if msg := rangerObjet(gs, nom); msg != "" {
+		fmt.Println(msg)
+	}
+	return true
+}
+
+// rangerObjet ajoute à l'inventaire un objet dont la place a déjà été faite (acquerirObjet, forge)
+// et retourne l'avertissement de surcharge éventuel, à afficher par l'appelant
+func rangerObjet(gs *GameState, nom string) string {
+	gs.Joueur.Inventaire = append(gs.Joueur.Inventaire, nom)
+	if malusSurcharge(&gs.Joueur) > 0 {
+		return fmt.Sprintf("⚠️ Vous êtes surchargé (%d/%d) : agilité et esquive réduites.", PoidsTotal(&gs.Joueur), PoidsMaxInventaire)
+	}
+	return ""
+}
+
+// jeterObjet propose de jeter un objet non équipé et retourne true si un objet a été jeté
+func jeterObjet(gs *GameState) bool {
+	j := &gs.Joueur
+	noms, counts := compterItems(j)
+	jetables := []string{}
+	opts := []string{}
+	for _, item := range noms {
+		if estArmeEquipee(j, item) || estArmureEquipee(j, item) {
+			continue
+		}
+		jetables = append(jetables, item)
+		opts = append(opts, fmt.Sprintf("%s x%d (poids %d)", item, counts[item], PoidsObjet(item)))
+	}
+	if len(jetables) == 0 {
+		fmt.Println("Aucun objet non équipé à jeter.")
+		attendreEntree()
+		return false
+	}
+	idx, cancelled := selectWithArrows("Jeter quel objet ?", opts)
+	if cancelled {
 		return false
 	}
-	j.Inventaire = append(j.Inventaire, objet)
+	retirerObjetParNom(j, jetables[idx])
+	delete(j.Exemplaires, jetables[idx])
+	fmt.Printf("🗑️ Vous jetez %s.\n", jetables[idx])
 	return true
 }
 
@@ -456,6 +568,17 @@ func retirerObjetParNom(j *personnage.Personnage, nom string) bool {
 	return false
 }
 
+// compterObjet compte les exemplaires d'un objet (insensible à la casse)
+func compterObjet(j *personnage.Personnage, nom string) int {
+	n := 0
+	for _, it := range j.Inventaire {
+		if strings.EqualFold(it, nom) {
+			n++
+		}
+	}
+	return n
+}
+
 func estInventaireVide(j *personnage.Personnage) bool {
 	return len(j.Inventaire) == 0
 }
@@ -488,17 +611,27 @@ func utiliserObjetNom(j *personnage.Personnage, nom string) bool {
 	case "elixir vie":
 		utiliserElixirVie(j)
 		return true
+	case "potion resistance feu":
+		utiliserPotionResistanceFeu(j)
+		return true
+	case "potion purification":
+		utiliserPotionPurification(j)
+		return true
+	case "potion regeneration":
+		utiliserPotionRegeneration(j)
+		return true
 	}
 
 	// Tente une correspondance avec les armes connues via clés et noms affichés
 	if arme, ok := trouverArmeParNom(nom); ok {
+		nomEquipe := nomAEquiper(nom, arme.Nom)
 		// Toggle: si déjà équipée, on déséquipe
-		if strings.EqualFold(j.Attaque, arme.Nom) {
+		if strings.EqualFold(j.Attaque, nomEquipe) {
 			j.Attaque = ""
-			fmt.Printf("🔪 Arme déséquipée: %s\n", arme.Nom)
+			fmt.Printf("🔪 Arme déséquipée: %s\n", nomEquipe)
 		} else {
-			j.Attaque = arme.Nom
-			fmt.Printf("🔪 Arme équipée: %s (Attaque %d)\n", arme.Nom, arme.EffetAttaque)
+			j.Attaque = nomEquipe
+			fmt.Printf("🔪 Arme équipée: %s (Attaque %d)\n", libelleExemplaire(j, nomEquipe), arme.EffetAttaque)
 			objet.AfficherArme(arme)
 		}
 		return true
@@ -513,13 +646,14 @@ func utiliserObjetNom(j *personnage.Personnage, nom string) bool {
 		if j.ArmuresEquipees == nil {
 			j.ArmuresEquipees = make(map[string]bool)
 		}
+		nomEquipe := nomAEquiper(nom, arm.Nom)
 		// Toggle equip/desequip
-		if j.ArmuresEquipees[arm.Nom] {
-			delete(j.ArmuresEquipees, arm.Nom)
-			fmt.Printf("🛡️ Armure déséquipée: %s\n", arm.Nom)
+		if j.ArmuresEquipees[nomEquipe] {
+			delete(j.ArmuresEquipees, nomEquipe)
+			fmt.Printf("🛡️ Armure déséquipée: %s\n", nomEquipe)
 		} else {
-			j.ArmuresEquipees[arm.Nom] = true
-			fmt.Printf("🛡️ Armure équipée: %s (DEF %d)\n", arm.Nom, arm.EffetDefense)
+			j.ArmuresEquipees[nomEquipe] = true
+			fmt.Printf("🛡️ Armure équipée: %s (DEF %d)\n", libelleExemplaire(j, nomEquipe), arm.EffetDefense)
 		}
 		objet.AfficherArmure(arm)
 		return true
@@ -529,6 +663,15 @@ func utiliserObjetNom(j *personnage.Personnage, nom string) bool {
 	return false
 }
 
+// nomAEquiper retourne le nom sous lequel un objet est équipé:
+// le nom unique pour un exemplaire, le nom affiché de base sinon
+func nomAEquiper(nom, nomBase string) string {
+	if objet.NomDeBase(nom) != nom {
+		return nom
+	}
+	return nomBase
+}
+
 // estArmeEquipee indique si le texte d'un item correspond à l'arme actuellement équipée
 func estArmeEquipee(j *personnage.Personnage, item string) bool {
 	if j.Attaque == "" {
@@ -536,7 +679,7 @@ func estArmeEquipee(j *personnage.Personnage, item string) bool {
 	}
 	// correspondance via clés et noms affichés
 	if arme, ok := trouverArmeParNom(item); ok {
-		return strings.EqualFold(j.Attaque, arme.Nom)
+		return strings.EqualFold(j.Attaque, nomAEquiper(item, arme.Nom))
 	}
 	return false
 }
@@ -548,7 +691,7 @@ func estArmureEquipee(j *personnage.Personnage, item string) bool {
 	}
 	// normaliser par nom d'affichage (objet.CreerArmure renvoie .Nom)
 	if arm, ok := trouverArmureParNom(item); ok {
-		return j.ArmuresEquipees[arm.Nom]
+		return j.ArmuresEquipees[nomAEquiper(item, arm.Nom)]
 	}
 	return false
 }
@@ -584,11 +727,11 @@ func utiliserObjetSelection(j *personnage.Personnage, indexAffiche int) bool {
 func trouverArmeParNom(nom string) (objet.Arme, bool) {
 	// Liste des clés d'armes supportées par objet.CreerArme
 	cles := []string{
-		"EpeeRouillee", "EpeeFer", "EpeeMagique", "EpeeCourte",
-		"Hache", "HacheDeCombat", "HacheDeBataille",
+		"EpeeRouillee", "EpeeFer", "EpeeMagique", "EpeeCourte", "EpeeDuMaitreForgeron",
+		"Hache", "HacheDeCombat", "HacheDeBataille", "HacheDeLaConfrerie",
 		"ArcBois", "ArcLong", "ArcElfe",
 	}
-	needle := strings.ToLower(strings.TrimSpace(nom))
+	needle := strings.ToLower(strings.TrimSpace(objet.NomDeBase(nom)))
 	for _, cle := range cles {
 		a := objet.CreerArme(cle)
 		if strings.EqualFold(needle, cle) || strings.EqualFold(needle, a.Nom) {
@@ -598,18 +741,33 @@ func trouverArmeParNom(nom string) (objet.Arme, bool) {
 	return objet.Arme{}, false
 }
 
+func trouverArmeMonstreParNom(nom string) (objet.ArmeMonstre, bool) {
+	cles := []string{
+		"GriffesSouillees", "MassueBrute", "LanceBrisee", "EpeeOsseuse",
+		"HacheTronquee", "GlaiveSauvage", "MasseRituelle", "FauxDeBrume",
+	}
+	needle := strings.ToLower(strings.TrimSpace(nom))
+	for _, cle := range cles {
+		a := objet.CreerArmeMonstre(cle)
+		if strings.EqualFold(needle, cle) || strings.EqualFold(needle, a.Nom) {
+			return a, true
+		}
+	}
+	return objet.ArmeMonstre{}, false
+}
+
 func trouverArmureParNom(nom string) (objet.Armure, bool) {
 	cles := []string{
 		// Casques
 		"CasqueCuir", "CasqueCuirRenforce", "CasqueFer", "CasqueFerRenforce",
 		// Plastrons
-		"PlastronCuir", "PlastronCuirRenforce", "PlastronFer", "PlastronFerRenforce",
+		"PlastronCuir", "PlastronCuirRenforce", "PlastronFer", "PlastronFerRenforce", "PlastronDeLaConfrerie",
 		// Pantalons
 		"PantalonCuir", "PantalonCuirRenforce", "PantalonFer", "PantalonFerRenforce",
 		// Chaussures
 		"BottesCuir", "BottesCuirRenforce", "BottesFer", "BottesFerRenforce",
 	}
-	needle := strings.ToLower(strings.TrimSpace(nom))
+	needle := strings.ToLower(strings.TrimSpace(objet.NomDeBase(nom)))
 	for _, cle := range cles {
 		ar := objet.CreerArmure(cle)
 		if strings.EqualFold(needle, cle) || strings.EqualFold(needle, ar.Nom) {
@@ -669,8 +827,8 @@ func utiliserPotionEndurance(j *personnage.Personnage) {
 
 func utiliserAntidote(j *personnage.Personnage) {
 	if retirerObjetParNom(j, "antidote") {
+		j.Statuts = nil
 		fmt.Printf("🧪 Antidote utilisé ! Tous les statuts négatifs sont guéris\n")
-		// Note: La logique de guérison des statuts sera gérée dans le combat
 		return
 	}
 	fmt.Println("❌ Vous n'avez pas d'antidote !")
@@ -687,3 +845,33 @@ func utiliserElixirVie(j *personnage.Personnage) {
 	}
 	fmt.Println("❌ Vous n'avez pas d'élixir de vie !")
 }
+
+func utiliserPotionResistanceFeu(j *personnage.Personnage) {
+	if retirerObjetParNom(j, "potion resistance feu") {
+		j.ResistanceFeu = 3
+		delete(j.Statuts, "burn")
+		fmt.Printf("🔥 Potion de résistance au feu utilisée ! Immunité aux brûlures pour 3 combats\n")
+		return
+	}
+	fmt.Println("❌ Vous n'avez pas de potion de résistance au feu !")
+}
+
+func utiliserPotionPurification(j *personnage.Personnage) {
+	if retirerObjetParNom(j, "potion purification") {
+		j.Statuts = nil
+		j.Purification = 2
+		fmt.Printf("🌿 Potion de purification utilisée ! Statuts guéris et immunité pour 2 combats\n")
+		return
+	}
+	fmt.Println("❌ Vous n'avez pas de potion de purification !")
+}
+
+func utiliserPotionRegeneration(j *personnage.Personnage) {
+	if retirerObjetParNom(j, "potion regeneration") {
+		j.RegenTours = 10
+		j.RegenPV = 8
+		fmt.Printf("💚 Potion de régénération utilisée ! +%d PV par tour de combat pendant %d tours\n", j.RegenPV, j.RegenTours)
+		return
+	}
+	fmt.Println("❌ Vous n'avez pas de potion de régénération !")
+}
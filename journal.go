@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"sloteriaa/struct/forgeron"
+)
+
+// Écriture du journal: un mouvement d'or ou de matériau
+type EcritureJournal struct {
+	Heure     int    // heures de jeu écoulées (gs.Heures) au moment du mouvement
+	Source    string // achat, vente, forge, butin...
+	Detail    string
+	Ressource string // "Or" ou nom du matériau
+	Montant   int    // positif: gain, négatif: dépense
+	Solde     int    // solde de la ressource après le mouvement
+}
+
+// Sources des écritures du journal
+const (
+	srcAchat         = "Achat"
+	srcVente         = "Vente"
+	srcRachat        = "Rachat"
+	srcForge         = "Forge"
+	srcAlchimie      = "Alchimie"
+	srcAmelioration  = "Amélioration"
+	srcEnchantement  = "Enchantement"
+	srcRaffinage     = "Raffinage"
+	srcDemantelement = "Démantèlement"
+	srcCoffre        = "Coffre"
+	srcAuberge       = "Auberge"
+	srcRecolte       = "Récolte"
+	srcQuete         = "Quête"
+	srcDialogue      = "Dialogue"
+	srcRecompense    = "Récompense"
+	srcDefaite       = "Défaite"
+	srcButin         = "Butin"
+)
+
+// Nombre d'écritures affichées à l'écran (l'export CSV contient tout)
+const ecrituresAffichees = 20
+
+// mouvementOr crédite (montant positif) ou débite l'or du joueur et l'inscrit au journal
+func mouvementOr(gs *GameState, source, detail string, montant int) {
+	if montant == 0 {
+		return
+	}
+	gs.Joueur.Argent += montant
+	inscrire(gs, source, detail, string(forgeron.Or), montant, gs.Joueur.Argent)
+}
+
+// mouvementMateriau crédite ou débite un matériau et l'inscrit au journal
+func mouvementMateriau(gs *GameState, source, detail string, m forgeron.Materiau, q int) {
+	if q == 0 {
+		return
+	}
+	gs.Mats[m] += q
+	inscrire(gs, source, detail, string(m), q, gs.Mats[m])
+}
+
+// payerCout débite un coût complet (or et matériaux) en l'inscrivant au journal
+func payerCout(gs *GameState, source, detail string, cout forgeron.Cout) {
+	for _, m := range forgeron.OrdreMateriaux {
+		q := cout[m]
+		if m == forgeron.Or {
+			mouvementOr(gs, source, detail, -q)
+		} else {
+			mouvementMateriau(gs, source, detail, m, -q)
+		}
+	}
+}
+
+// Nombre d'écritures conservées dans la sauvegarde: les plus anciennes sont oubliées,
+// mais les totaux par source (BilanOr) restent complets
+const maxEcrituresJournal = 1000
+
+// Or gagné et dépensé par une source depuis le début de la partie
+type BilanSource struct {
+	Gains    int
+	Depenses int
+}
+
+func inscrire(gs *GameState, source, detail, ressource string, montant, solde int) {
+	gs.Journal = append(gs.Journal, EcritureJournal{
+		Heure:     gs.Heures,
+		Source:    source,
+		Detail:    detail,
+		Ressource: ressource,
+		Montant:   montant,
+		Solde:     solde,
+	})
+	if len(gs.Journal) > maxEcrituresJournal {
+		gs.Journal = gs.Journal[len(gs.Journal)-maxEcrituresJournal:]
+	}
+	if ressource == string(forgeron.Or) {
+		if gs.BilanOr == nil {
+			gs.BilanOr = make(map[string]BilanSource)
+		}
+		gs.BilanOr[source] = ajouterAuBilan(gs.BilanOr[source], montant)
+	}
+}
+
+func ajouterAuBilan(b BilanSource, montant int) BilanSource {
+	if montant > 0 {
+		b.Gains += montant
+	} else {
+		b.Depenses -= montant
+	}
+	return b
+}
+
+// migrerJournal reconstitue les totaux des anciennes sauvegardes et limite la taille du journal
+func migrerJournal(gs *GameState) {
+	if gs.BilanOr == nil {
+		gs.BilanOr = make(map[string]BilanSource)
+		for _, e := range gs.Journal {
+			if e.Ressource == string(forgeron.Or) {
+				gs.BilanOr[e.Source] = ajouterAuBilan(gs.BilanOr[e.Source], e.Montant)
+			}
+		}
+	}
+	if len(gs.Journal) > maxEcrituresJournal {
+		gs.Journal = gs.Journal[len(gs.Journal)-maxEcrituresJournal:]
+	}
+}
+
+// EnterJournal affiche les dernières écritures, les statistiques et l'export CSV
+func EnterJournal(gs *GameState) {
+	for {
+		header := fmt.Sprintf("Journal des comptes — %d écriture(s) — Or %d", len(gs.Journal), gs.Joueur.Argent)
+		idx, cancelled := selectWithArrows(header, []string{"Dernières écritures", "Statistiques par source", "Exporter en CSV", "Retour"})
+		if cancelled || idx == 3 {
+			return
+		}
+		switch idx {
+		case 0:
+			afficherEcritures(gs)
+		case 1:
+			afficherStatistiques(gs)
+		case 2:
+			if fichier, err := exporterJournal(gs); err != nil {
+				fmt.Printf("Erreur d'export: %s\n", err)
+			} else {
+				fmt.Printf("📒 Journal exporté dans %s\n", fichier)
+			}
+			attendreEntree()
+		}
+	}
+}
+
+func afficherEcritures(gs *GameState) {
+	clearScreen()
+	fmt.Printf("Dernières écritures (%d sur %d)\n\n", min(ecrituresAffichees, len(gs.Journal)), len(gs.Journal))
+	debut := max(0, len(gs.Journal)-ecrituresAffichees)
+	for i := len(gs.Journal) - 1; i >= debut; i-- {
+		e := gs.Journal[i]
+		fmt.Printf("  %s  %-14s %+6d %-16s (solde %d)  %s\n", dateDuJeu(e.Heure), e.Source, e.Montant, e.Ressource, e.Solde, e.Detail)
+	}
+	if len(gs.Journal) == 0 {
+		fmt.Println("  Aucun mouvement enregistré.")
+	}
+	fmt.Println()
+	attendreEntree()
+}
+
+// afficherStatistiques affiche l'or gagné et dépensé par source depuis le début de la partie
+func afficherStatistiques(gs *GameState) {
+	clearScreen()
+	sources := make([]string, 0, len(gs.BilanOr))
+	for s := range gs.BilanOr {
+		sources = append(sources, s)
+	}
+	sort.Strings(sources)
+	fmt.Println("Or par source")
+	fmt.Println()
+	totalGains, totalDepenses := 0, 0
+	for _, s := range sources {
+		b := gs.BilanOr[s]
+		fmt.Printf("  %-14s +%-8d -%-8d net %+d\n", s, b.Gains, b.Depenses, b.Gains-b.Depenses)
+		totalGains += b.Gains
+		totalDepenses += b.Depenses
+	}
+	fmt.Println()
+	fmt.Printf("  %-14s +%-8d -%-8d net %+d\n", "Total", totalGains, totalDepenses, totalGains-totalDepenses)
+	fmt.Println()
+	attendreEntree()
+}
+
+// exporterJournal écrit les écritures conservées dans un fichier CSV horodaté et retourne son nom.
+// La colonne heure_jeu (heures écoulées depuis le début de la partie) sert d'axe pour tracer l'économie.
+func exporterJournal(gs *GameState) (string, error) {
+	fichier := fmt.Sprintf("journal_%s.csv", time.Now().Format("20060102_150405"))
+	f, err := os.Create(fichier)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	w.Write([]string{"heure_jeu", "date_jeu", "source", "detail", "ressource", "montant", "solde"})
+	for _, e := range gs.Journal {
+		w.Write([]string{strconv.Itoa(e.Heure), dateDuJeu(e.Heure), e.Source, e.Detail, e.Ressource, strconv.Itoa(e.Montant), strconv.Itoa(e.Solde)})
+	}
+	w.Flush()
+	return fichier, w.Error()
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+
+	"sloteriaa/struct/forgeron"
+	"sloteriaa/struct/objet"
+)
+
+// Catégories d'objets rachetés par les marchands
+const (
+	catConsommable = "consommable"
+	catArme        = "arme"
+	catArmure      = "armure"
+	catButin       = "butin" // armes ramassées sur les monstres
+)
+
+// Article proposé en permanence, avec son stock maximal
+type articleStock struct {
+	Nom string
+	Max int
+}
+
+// Article rare: n'apparaît qu'avec une certaine chance à chaque réapprovisionnement
+type articleRare struct {
+	Nom    string
+	Max    int
+	Chance int // en %
+}
+
+// Marchand de la ville: articles vendus, préférences de rachat et marge propre
+type Marchand struct {
+	Cle            string
+	Nom            string
+	Description    string
+	Articles       []articleStock
+	Rares          []articleRare
+	Marge          int            // % appliqué au cours du marché pour les ventes au joueur
+	Rachat         map[string]int // catégorie -> % du prix de rachat habituel; absente: n'achète pas
+	ChancePresence int            // 0: toujours en ville, sinon chance (%) d'être là à chaque réapprovisionnement
+	Ouverture      int            // heure d'ouverture de la boutique
+	Fermeture      int            // heure de fermeture (exclue)
+}
+
+func marchands() []Marchand {
+	return []Marchand{
+		{
+			Cle: "apothicaire", Nom: "Apothicaire", Description: "potions et remèdes",
+			Articles:  []articleStock{{"potion", 10}, {"potion majeure", 5}, {"potion force", 3}, {"potion agilite", 3}, {"potion endurance", 3}, {"antidote", 6}},
+			Rares:     []articleRare{{"elixir vie", 2, 50}, {"potion purification", 2, 25}, {"potion regeneration", 2, 25}},
+			Marge:     100,
+			Rachat:    map[string]int{catConsommable: 120},
+			Ouverture: 8, Fermeture: 20,
+		},
+		{
+			Cle: "ferronnier", Nom: "Ferronnier", Description: "fer, minerai et bois",
+			Articles:  []articleStock{{string(forgeron.Fer), 30}, {string(forgeron.MineraiDeFer), 40}, {string(forgeron.Bois), 30}},
+			Rares:     []articleRare{{parcheminProtection, 1, 30}, {string(forgeron.EssenceMagique), 2, 30}},
+			Marge:     100,
+			Rachat:    map[string]int{catArme: 100, catArmure: 80},
+			Ouverture: 7, Fermeture: 19,
+		},
+		{
+			Cle: "tanneur", Nom: "Tanneur", Description: "cuirs et peaux",
+			Articles:  []articleStock{{string(forgeron.Cuir), 25}, {string(forgeron.PeauBrute), 30}},
+			Marge:     95,
+			Rachat:    map[string]int{catArmure: 110},
+			Ouverture: 7, Fermeture: 18,
+		},
+		{
+			Cle: "antiquaire", Nom: "Antiquaire", Description: "rachète le butin des monstres",
+			Marge:  100,
+			Rachat: map[string]int{catButin: 140, catArme: 70, catArmure: 70},
+			// L'antiquaire reçoit tard: le butin se vend à la tombée de la nuit
+			Ouverture: 12, Fermeture: 23,
+		},
+		{
+			Cle: "ambulant", Nom: "Marchand ambulant", Description: "raretés de passage",
+			Rares: []articleRare{{"elixir vie", 2, 60}, {parcheminProtection, 2, 60}, {string(forgeron.EssenceMagique), 3, 50},
+				{string(forgeron.CristalBrut), 4, 40}, {"potion purification", 2, 40}, {"potion regeneration", 2, 40}},
+			Marge:          130,
+			Rachat:         map[string]int{catConsommable: 80, catArme: 80, catArmure: 80, catButin: 80},
+			ChancePresence: 35,
+			Ouverture:      6, Fermeture: 21,
+		},
+	}
+}
+
+// estOuvert indique si la boutique du marchand est ouverte à l'heure actuelle
+func estOuvert(gs *GameState, m Marchand) bool {
+	h := heureDuJour(gs)
+	return h >= m.Ouverture && h < m.Fermeture
+}
+
+// Paliers de réputation: or échangé avec le marchand requis et remise accordée (en %)
+var paliersReputation = []struct {
+	Seuil  int
+	Titre  string
+	Remise int
+}{
+	{0, "Inconnu", 0},
+	{500, "Habitué", 5},
+	{1500, "Estimé", 10},
+	{4000, "Ami de la maison", 15},
+}
+
+// palierReputation retourne le titre et la remise obtenus auprès d'un marchand
+func palierReputation(gs *GameState, cle string) (string, int) {
+	or := gs.ReputationMarchands[cle]
+	titre, remise := paliersReputation[0].Titre, paliersReputation[0].Remise
+	for _, p := range paliersReputation {
+		if or >= p.Seuil {
+			titre, remise = p.Titre, p.Remise
+		}
+	}
+	return titre, remise
+}
+
+// gagnerReputation ajoute l'or échangé à la réputation et annonce un nouveau palier
+func gagnerReputation(gs *GameState, cle string, or int) {
+	if gs.ReputationMarchands == nil {
+		gs.ReputationMarchands = make(map[string]int)
+	}
+	avant, _ := palierReputation(gs, cle)
+	gs.ReputationMarchands[cle] += or
+	if apres, remise := palierReputation(gs, cle); apres != avant {
+		fmt.Printf("🤝 Réputation: vous êtes désormais « %s » (remise %d%%).\n", apres, remise)
+	}
+}
+
+// remiseMarchand cumule la remise du marchand et celle de la guilde des marchands
+func remiseMarchand(gs *GameState, m Marchand) int {
+	_, remise := palierReputation(gs, m.Cle)
+	return remise + remiseGuilde(gs)
+}
+
+// prixBase retourne le prix de base d'un article vendu par les marchands
+func prixBase(nom string) (int, bool) {
+	if c, ok := objet.TrouverConsommable(nom); ok {
+		return c.Valeur * margeConsommable, true
+	}
+	p, ok := materialPrices[forgeron.Materiau(nom)]
+	return p, ok
+}
+
+// prixAchat retourne le prix d'un article chez un marchand: cours du marché, marge et remises de réputation
+func prixAchat(gs *GameState, m Marchand, nom string) int {
+	base, _ := prixBase(nom)
+	return prixAvecMarge(gs, m, prixMarche(gs, nom, base))
+}
+
+func prixAvecMarge(gs *GameState, m Marchand, prixCours int) int {
+	return max(1, prixCours*m.Marge/100*(100-remiseMarchand(gs, m))/100)
+}
+
+// prixAchatLot retourne le coût de n unités achetées une à une: chaque unité fait monter le cours
+func prixAchatLot(gs *GameState, m Marchand, nom string, n int) int {
+	base, _ := prixBase(nom)
+	c := coursDe(gs, nom, base)
+	total := 0
+	for i := 0; i < n; i++ {
+		total += prixAvecMarge(gs, m, c.Prix())
+		c = c.apres(1, true)
+	}
+	return total
+}
+
+// maxAchetable retourne combien d'unités l'or permet d'acheter au cours montant, dans la limite de plafond
+func maxAchetable(gs *GameState, m Marchand, nom string, plafond int) int {
+	n := 0
+	for n < plafond && prixAchatLot(gs, m, nom, n+1) <= gs.Joueur.Argent {
+		n++
+	}
+	return n
+}
+
+// categorieVente classe un objet de l'inventaire pour les préférences de rachat
+func categorieVente(name string) string {
+	if _, ok := objet.TrouverConsommable(name); ok {
+		return catConsommable
+	}
+	base := objet.NomDeBase(name)
+	if _, ok := trouverArmeParNom(base); ok {
+		return catArme
+	}
+	if _, ok := trouverArmureParNom(base); ok {
+		return catArmure
+	}
+	if _, ok := trouverArmeMonstreParNom(base); ok {
+		return catButin
+	}
+	return ""
+}
+
+// prixVenteChez retourne ce que le marchand paie pour un objet, ou false s'il n'en veut pas
+func prixVenteChez(gs *GameState, m Marchand, name string) (int, bool) {
+	pct, ok := m.Rachat[categorieVente(name)]
+	if !ok {
+		return 0, false
+	}
+	prix, ok := prixVente(gs, name)
+	if !ok {
+		return 0, false
+	}
+	return max(1, prix*pct/100*(100+remiseMarchand(gs, m))/100), true
+}
+
+// descriptionArticle résume l'effet d'un article pour les menus d'achat
+func descriptionArticle(it string) string {
+	switch it {
+	case "potion":
+		return " (+20 PV)"
+	case "potion majeure":
+		return " (+50 PV)"
+	case "potion force":
+		return " (+2 Force, 3 combats)"
+	case "potion agilite":
+		return " (+2 Agilité, 3 combats)"
+	case "potion endurance":
+		return " (+2 Endurance, 3 combats)"
+	case "antidote":
+		return " (Guérit statuts)"
+	case "elixir vie":
+		return " (+100 PV)"
+	case parcheminProtection:
+		return " (Protège une amélioration)"
+	case "potion purification":
+		return " (Guérit et immunise, 2 combats)"
+	case "potion regeneration":
+		return " (+8 PV/tour, 10 tours)"
+	}
+	return ""
+}
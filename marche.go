@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Cours d'un article: écart au prix de base (en %) et historique des derniers prix
+type Cours struct {
+	Base       int
+	Pourcent   int // 100 = prix de base
+	Historique []int
+}
+
+const (
+	variationParUnite = 3   // % de hausse (achat) ou de baisse (vente) par unité échangée
+	pourcentMin       = 40  // plancher du cours
+	pourcentMax       = 250 // plafond du cours
+	tailleHistorique  = 8
+)
+
+// coursDe retourne le cours d'un article, créé au prix de base s'il n'existe pas encore
+func coursDe(gs *GameState, cle string, base int) Cours {
+	if gs.Marche == nil {
+		gs.Marche = make(map[string]Cours)
+	}
+	c, ok := gs.Marche[cle]
+	if !ok {
+		c = Cours{Base: base, Pourcent: 100, Historique: []int{base}}
+	}
+	c.Base = base
+	return c
+}
+
+func (c Cours) Prix() int {
+	p := c.Base * c.Pourcent / 100
+	if p < 1 {
+		p = 1
+	}
+	return p
+}
+
+// prixMarche retourne le prix actuel d'un article
+func prixMarche(gs *GameState, cle string, base int) int {
+	return coursDe(gs, cle, base).Prix()
+}
+
+// apres retourne le cours après l'échange de quantite unités (hausse à l'achat, baisse à la vente)
+func (c Cours) apres(quantite int, achat bool) Cours {
+	delta := variationParUnite * quantite
+	if !achat {
+		delta = -delta
+	}
+	c.Pourcent += delta
+	if c.Pourcent < pourcentMin {
+		c.Pourcent = pourcentMin
+	}
+	if c.Pourcent > pourcentMax {
+		c.Pourcent = pourcentMax
+	}
+	return c
+}
+
+// enregistrerEchange fait monter (achat) ou baisser (vente) le cours selon la quantité échangée
+func enregistrerEchange(gs *GameState, cle string, base int, quantite int, achat bool) {
+	gs.Marche[cle] = coursDe(gs, cle, base).apres(quantite, achat)
+}
+
+// deriveMarche ramène chaque cours d'un tiers vers son prix de base et note le prix dans l'historique.
+// Appelée après chaque expédition au donjon.
+func deriveMarche(gs *GameState) {
+	for cle, c := range gs.Marche {
+		ecart := 100 - c.Pourcent
+		pas := ecart / 3
+		if pas == 0 && ecart != 0 {
+			pas = ecart / abs(ecart)
+		}
+		c.Pourcent += pas
+		c.Historique = append(c.Historique, c.Prix())
+		if len(c.Historique) > tailleHistorique {
+			c.Historique = c.Historique[len(c.Historique)-tailleHistorique:]
+		}
+		gs.Marche[cle] = c
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// tendance résume le cours: flèche par rapport au prix de base
+func tendance(gs *GameState, cle string, base int) string {
+	c := coursDe(gs, cle, base)
+	switch {
+	case c.Pourcent > 100:
+		return fmt.Sprintf("▲%d%%", c.Pourcent-100)
+	case c.Pourcent < 100:
+		return fmt.Sprintf("▼%d%%", 100-c.Pourcent)
+	default:
+		return "="
+	}
+}
+
+// courbe dessine l'historique des prix avec des blocs de hauteur variable
+func courbe(historique []int) string {
+	if len(historique) == 0 {
+		return ""
+	}
+	blocs := []rune("▁▂▃▄▅▆▇█")
+	bas, haut := historique[0], historique[0]
+	for _, p := range historique {
+		if p < bas {
+			bas = p
+		}
+		if p > haut {
+			haut = p
+		}
+	}
+	var b strings.Builder
+	for _, p := range historique {
+		i := 0
+		if haut > bas {
+			i = (p - bas) * (len(blocs) - 1) / (haut - bas)
+		}
+		b.WriteRune(blocs[i])
+	}
+	return b.String()
+}
+
+// afficherCoursMarche liste les articles échangés avec leur historique de prix
+func afficherCoursMarche(gs *GameState) {
+	clearScreen()
+	fmt.Println("Cours du marché (historique par expédition)")
+	fmt.Println()
+	if len(gs.Marche) == 0 {
+		fmt.Println("Aucun échange pour l'instant: les prix sont à leur valeur de base.")
+	}
+	cles := make([]string, 0, len(gs.Marche))
+	for cle := range gs.Marche {
+		cles = append(cles, cle)
+	}
+	sort.Strings(cles)
+	for _, cle := range cles {
+		c := gs.Marche[cle]
+		fmt.Printf("  %-22s %4d or (base %d, %s)  %s\n", cle, c.Prix(), c.Base, tendance(gs, cle, c.Base), courbe(c.Historique))
+	}
+	fmt.Println()
+	attendreEntree()
+}
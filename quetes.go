@@ -0,0 +1,431 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"sloteriaa/struct/forgeron"
+)
+
+//go:embed data/quetes.json
+var donneesQuetes []byte
+
+// Quête décrite dans data/quetes.json
+type Quete struct {
+	Cle         string      `json:"cle"`
+	Titre       string      `json:"titre"`
+	Principale  bool        `json:"principale"`
+	Description string      `json:"description"`
+	Niveau      int         `json:"niveau"`    // niveau minimal pour l'accepter
+	Donneur     string      `json:"donneur"`   // PNJ qui propose la quête; vide: tableau des quêtes
+	Prerequis   []string    `json:"prerequis"` // quêtes à terminer avant
+	Objectifs   []Objectif  `json:"objectifs"`
+	Recompenses Recompenses `json:"recompenses"`
+}
+
+// Objectif d'une quête; Cible vide: n'importe quel monstre, objet ou article
+type Objectif struct {
+	Type     string `json:"type"`
+	Cible    string `json:"cible"`
+	Quantite int    `json:"quantite"`
+	Libelle  string `json:"libelle"`
+}
+
+type Recompenses struct {
+	Or         int            `json:"or"`
+	XP         int            `json:"xp"`
+	Objets     []string       `json:"objets"`
+	Recettes   []string       `json:"recettes"`
+	Reputation map[string]int `json:"reputation"`
+}
+
+// Types d'objectifs, aussi utilisés comme événements envoyés par le combat, la forge et le marché
+const (
+	objTuer      = "tuer"      // monstre vaincu (cible: nom du monstre)
+	objTier      = "tier"      // victoire à un tier au moins égal à la quantité
+	objFabriquer = "fabriquer" // équipement forgé (cible: clé de recette)
+	objBrasser   = "brasser"   // potion brassée à l'atelier d'alchimie (cible: objet obtenu)
+	objVendre    = "vendre"    // objet vendu à un marchand (cible: nom de base)
+	objLivrer    = "livrer"    // matériaux remis depuis le journal de quêtes (cible: matériau)
+)
+
+// État d'une quête acceptée: progression de chaque objectif
+type EtatQuete struct {
+	Progres  []int
+	Terminee bool
+}
+
+var (
+	quetes         []Quete
+	quetesChargees sync.Once
+)
+
+// toutesLesQuetes charge et vérifie les quêtes embarquées au premier accès
+func toutesLesQuetes() []Quete {
+	quetesChargees.Do(func() {
+		if err := json.Unmarshal(donneesQuetes, &quetes); err != nil {
+			panic(fmt.Sprintf("quêtes invalides: %s", err))
+		}
+		if err := verifierQuetes(quetes); err != nil {
+			panic(fmt.Sprintf("quêtes invalides: %s", err))
+		}
+	})
+	return quetes
+}
+
+// verifierQuetes contrôle les prérequis, objectifs et récompenses du fichier de quêtes
+func verifierQuetes(qs []Quete) error {
+	cles := map[string]bool{}
+	for _, q := range qs {
+		cles[q.Cle] = true
+	}
+	recettes := map[string]bool{}
+	for _, e := range toutesLesRecettes() {
+		recettes[e.Cle] = true
+	}
+	for _, q := range qs {
+		for _, p := range q.Prerequis {
+			if !cles[p] {
+				return fmt.Errorf("%s: prérequis inconnu: %s", q.Cle, p)
+			}
+		}
+		for _, o := range q.Objectifs {
+			switch o.Type {
+			case objTuer, objTier, objVendre:
+			case objFabriquer:
+				if o.Cible != "" && !recettes[o.Cible] {
+					return fmt.Errorf("%s: recette inconnue: %s", q.Cle, o.Cible)
+				}
+			case objBrasser:
+				if o.Cible != "" && !potionBrassable(o.Cible) {
+					return fmt.Errorf("%s: potion inconnue: %s", q.Cle, o.Cible)
+				}
+			case objLivrer:
+				if _, ok := forgeron.MateriauDepuisNom(o.Cible); !ok {
+					return fmt.Errorf("%s: matériau inconnu: %s", q.Cle, o.Cible)
+				}
+			default:
+				return fmt.Errorf("%s: type d'objectif inconnu: %s", q.Cle, o.Type)
+			}
+		}
+		for _, r := range q.Recompenses.Recettes {
+			if !recettes[r] {
+				return fmt.Errorf("%s: recette de récompense inconnue: %s", q.Cle, r)
+			}
+		}
+		for f := range q.Recompenses.Reputation {
+			if _, ok := factionParCle(f); !ok {
+				return fmt.Errorf("%s: faction inconnue: %s", q.Cle, f)
+			}
+		}
+	}
+	return nil
+}
+
+func queteParCle(cle string) (Quete, bool) {
+	for _, q := range toutesLesQuetes() {
+		if q.Cle == cle {
+			return q, true
+		}
+	}
+	return Quete{}, false
+}
+
+func queteTerminee(gs *GameState, cle string) bool {
+	e, ok := gs.Quetes[cle]
+	return ok && e.Terminee
+}
+
+// queteDisponible indique si la quête peut être acceptée maintenant
+func queteDisponible(gs *GameState, q Quete) bool {
+	if _, ok := gs.Quetes[q.Cle]; ok || gs.Level < q.Niveau {
+		return false
+	}
+	for _, p := range q.Prerequis {
+		if !queteTerminee(gs, p) {
+			return false
+		}
+	}
+	return true
+}
+
+// accepterQuete ajoute la quête au journal (tableau des quêtes, dialogues...); false si elle n'est pas disponible
+func accepterQuete(gs *GameState, cle string) bool {
+	q, ok := queteParCle(cle)
+	if !ok || !queteDisponible(gs, q) {
+		return false
+	}
+	if gs.Quetes == nil {
+		gs.Quetes = make(map[string]*EtatQuete)
+	}
+	gs.Quetes[cle] = &EtatQuete{Progres: make([]int, len(q.Objectifs))}
+	fmt.Printf("📜 Quête acceptée : %s\n", q.Titre)
+	return true
+}
+
+// Progression accordée à un objectif, gardée pour pouvoir l'annuler (rachat d'un objet vendu)
+type creditQuete struct {
+	Quete    string
+	Objectif int
+	N        int
+}
+
+// signalerQuete fait progresser les objectifs actifs correspondant à l'événement
+// (combat, forge, alchimie, marché), termine les quêtes accomplies et retourne la progression accordée
+func signalerQuete(gs *GameState, typ, cible string, n int) []creditQuete {
+	credits := []creditQuete{}
+	for _, q := range toutesLesQuetes() {
+		e, ok := gs.Quetes[q.Cle]
+		if !ok || e.Terminee {
+			continue
+		}
+		for i, o := range q.Objectifs {
+			if o.Type != typ || e.Progres[i] >= o.Quantite {
+				continue
+			}
+			avant := e.Progres[i]
+			switch {
+			case typ == objTier:
+				e.Progres[i] = min(o.Quantite, max(e.Progres[i], n))
+			case o.Cible == "" || o.Cible == cible:
+				e.Progres[i] = min(o.Quantite, e.Progres[i]+n)
+			default:
+				continue
+			}
+			if e.Progres[i] > avant {
+				credits = append(credits, creditQuete{q.Cle, i, e.Progres[i] - avant})
+			}
+			if e.Progres[i] >= o.Quantite {
+				fmt.Printf("📜 %s — objectif accompli : %s\n", q.Titre, o.Libelle)
+			}
+		}
+		verifierFinQuete(gs, q)
+	}
+	return credits
+}
+
+// queteTermineePar indique si l'une des progressions a contribué à une quête désormais terminée
+func queteTermineePar(gs *GameState, credits []creditQuete) bool {
+	for _, c := range credits {
+		if e, ok := gs.Quetes[c.Quete]; ok && e.Terminee {
+			return true
+		}
+	}
+	return false
+}
+
+// annulerQuete retire la progression accordée par signalerQuete aux quêtes encore en cours
+func annulerQuete(gs *GameState, credits []creditQuete) {
+	for _, c := range credits {
+		if e, ok := gs.Quetes[c.Quete]; ok && !e.Terminee {
+			e.Progres[c.Objectif] = max(0, e.Progres[c.Objectif]-c.N)
+		}
+	}
+}
+
+// verifierFinQuete termine la quête et verse les récompenses si tous les objectifs sont atteints
+func verifierFinQuete(gs *GameState, q Quete) {
+	e := gs.Quetes[q.Cle]
+	for i, o := range q.Objectifs {
+		if e.Progres[i] < o.Quantite {
+			return
+		}
+	}
+	e.Terminee = true
+	fmt.Printf("🏆 Quête terminée : %s !\n", q.Titre)
+	r := q.Recompenses
+	if r.Or > 0 {
+		mouvementOr(gs, srcQuete, q.Titre, r.Or)
+		fmt.Printf("💰 Vous recevez %d or.\n", r.Or)
+	}
+	for _, obj := range r.Objets {
+		if acquerirObjet(gs, obj) {
+			fmt.Printf("🎁 Vous recevez %s.\n", obj)
+		}
+	}
+	for _, cle := range r.Recettes {
+		debloquerRecette(gs, cle, "quête "+q.Titre)
+	}
+	for _, f := range factions {
+		modifierReputation(gs, f.Cle, r.Reputation[f.Cle])
+	}
+	if r.XP > 0 {
+		fmt.Printf("✨ +%d XP\n", r.XP)
+		gainXP(gs, r.XP)
+	}
+	accepterQuetesPrincipales(gs)
+}
+
+// accepterQuetesPrincipales ajoute au journal les quêtes principales devenues disponibles
+func accepterQuetesPrincipales(gs *GameState) {
+	for _, q := range toutesLesQuetes() {
+		if q.Principale && queteDisponible(gs, q) {
+			accepterQuete(gs, q.Cle)
+		}
+	}
+}
+
+// livrerQuete remet les matériaux demandés par les objectifs de livraison encore ouverts
+func livrerQuete(gs *GameState, q Quete) {
+	e := gs.Quetes[q.Cle]
+	livre := false
+	for i, o := range q.Objectifs {
+		if o.Type != objLivrer || e.Progres[i] >= o.Quantite {
+			continue
+		}
+		m, _ := forgeron.MateriauDepuisNom(o.Cible)
+		n := min(gs.Mats[m], o.Quantite-e.Progres[i])
+		if n <= 0 {
+			fmt.Printf("Vous n'avez pas de %s.\n", m)
+			continue
+		}
+		mouvementMateriau(gs, srcQuete, q.Titre, m, -n)
+		e.Progres[i] += n
+		livre = true
+		fmt.Printf("📦 Livré: %s x%d (%d/%d)\n", m, n, e.Progres[i], o.Quantite)
+	}
+	if livre {
+		verifierFinQuete(gs, q)
+	}
+	attendreEntree()
+}
+
+// libelleObjectifs résume la progression de chaque objectif
+func libelleObjectifs(q Quete, e *EtatQuete) []string {
+	lignes := []string{}
+	for i, o := range q.Objectifs {
+		coche := "☐"
+		progres := 0
+		if e != nil {
+			progres = e.Progres[i]
+		}
+		if progres >= o.Quantite {
+			coche = "☑"
+		}
+		if o.Type == objTier {
+			lignes = append(lignes, fmt.Sprintf("  %s %s", coche, o.Libelle))
+		} else {
+			lignes = append(lignes, fmt.Sprintf("  %s %s (%d/%d)", coche, o.Libelle, progres, o.Quantite))
+		}
+	}
+	return lignes
+}
+
+func titreQuete(q Quete) string {
+	if q.Principale {
+		return "★ " + q.Titre
+	}
+	return q.Titre
+}
+
+// EnterQuetes affiche le journal de quêtes: quêtes en cours, tableau des quêtes disponibles et quêtes terminées
+func EnterQuetes(gs *GameState) {
+	for {
+		enCours := []Quete{}
+		disponibles := []Quete{}
+		terminees := 0
+		for _, q := range toutesLesQuetes() {
+			e, ok := gs.Quetes[q.Cle]
+			switch {
+			case ok && e.Terminee:
+				terminees++
+			case ok:
+				enCours = append(enCours, q)
+			case queteDisponible(gs, q) && q.Donneur == "":
+				disponibles = append(disponibles, q)
+			}
+		}
+		opts := []string{}
+		for _, q := range enCours {
+			opts = append(opts, "📜 "+titreQuete(q))
+		}
+		opts = append(opts, fmt.Sprintf("Tableau des quêtes (%d disponible(s))", len(disponibles)), fmt.Sprintf("Quêtes terminées (%d)", terminees), "Retour")
+		idx, cancelled := selectWithArrows(fmt.Sprintf("Journal de quêtes — %d en cours", len(enCours)), opts)
+		if cancelled || idx == len(opts)-1 {
+			return
+		}
+		switch idx - len(enCours) {
+		case 0:
+			tableauQuetes(gs, disponibles)
+		case 1:
+			afficherQuetesTerminees(gs)
+		default:
+			detailQuete(gs, enCours[idx])
+		}
+	}
+}
+
+func detailQuete(gs *GameState, q Quete) {
+	e := gs.Quetes[q.Cle]
+	header := fmt.Sprintf("%s\n%s\n\n", titreQuete(q), q.Description)
+	for _, l := range libelleObjectifs(q, e) {
+		header += l + "\n"
+	}
+	opts := []string{}
+	aLivrer := false
+	for i, o := range q.Objectifs {
+		if o.Type == objLivrer && e.Progres[i] < o.Quantite {
+			aLivrer = true
+		}
+	}
+	if aLivrer {
+		opts = append(opts, "Livrer les matériaux")
+	}
+	opts = append(opts, "Abandonner la quête", "Retour")
+	idx, cancelled := selectWithArrows(header, opts)
+	if cancelled {
+		return
+	}
+	if !aLivrer {
+		idx++
+	}
+	switch idx {
+	case 0:
+		livrerQuete(gs, q)
+	case 1:
+		if i, c := selectWithArrows(fmt.Sprintf("Abandonner « %s » ? La progression sera perdue.", q.Titre), []string{"Oui", "Non"}); !c && i == 0 {
+			delete(gs.Quetes, q.Cle)
+		}
+	}
+}
+
+func tableauQuetes(gs *GameState, disponibles []Quete) {
+	if len(disponibles) == 0 {
+		fmt.Println("Aucune nouvelle quête pour le moment.")
+		attendreEntree()
+		return
+	}
+	opts := make([]string, 0, len(disponibles)+1)
+	for _, q := range disponibles {
+		opts = append(opts, fmt.Sprintf("%s — %s", titreQuete(q), q.Description))
+	}
+	opts = append(opts, "Retour")
+	idx, cancelled := selectWithArrows("Tableau des quêtes", opts)
+	if cancelled || idx == len(disponibles) {
+		return
+	}
+	q := disponibles[idx]
+	header := fmt.Sprintf("%s\n%s\n", titreQuete(q), q.Description)
+	for _, l := range libelleObjectifs(q, nil) {
+		header += "\n" + l
+	}
+	if i, c := selectWithArrows(header, []string{"Accepter", "Plus tard"}); !c && i == 0 {
+		accepterQuete(gs, q.Cle)
+		attendreEntree()
+	}
+}
+
+func afficherQuetesTerminees(gs *GameState) {
+	clearScreen()
+	fmt.Println("Quêtes terminées")
+	fmt.Println()
+	for _, q := range toutesLesQuetes() {
+		if queteTerminee(gs, q.Cle) {
+			fmt.Printf("  🏆 %s\n", titreQuete(q))
+		}
+	}
+	fmt.Println()
+	attendreEntree()
+}
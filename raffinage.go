@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"sloteriaa/struct/forgeron"
+)
+
+// forgeRaffiner convertit les matériaux bruts en matériaux de forge, contre des frais en or
+func forgeRaffiner(gs *GameState) {
+	recs := forgeron.RecettesRaffinage()
+	for {
+		opts := make([]string, 0, len(recs)+1)
+		for _, r := range recs {
+			opts = append(opts, fmt.Sprintf("%s x%d ← %s + %d or (possible: %d)",
+				r.Produit, r.Quantite, formatMaterials(r.Cout), r.Cout[forgeron.Or], maxAbordable(gs, r.Cout, 0)))
+		}
+		opts = append(opts, "Retour")
+		header := fmt.Sprintf("Raffinage — Or %d\nMatériaux: %s", gs.Joueur.Argent, formatMaterials(forgeron.Cout(gs.Mats)))
+		idx, cancelled := selectWithArrows(header, opts)
+		if cancelled || idx == len(recs) {
+			return
+		}
+		raffiner(gs, recs[idx])
+	}
+}
+
+// raffiner propose de traiter une fois ou tout le stock raffinable
+func raffiner(gs *GameState, r forgeron.RecetteRaffinage) {
+	n := maxAbordable(gs, r.Cout, 0)
+	if n == 0 {
+		// Affiche ce qui manque
+		ressourcesSuffisantes(gs, r.Cout)
+		return
+	}
+	idx, cancelled := selectWithArrows(fmt.Sprintf("Raffiner %s:", r.Produit), []string{
+		fmt.Sprintf("Une fois (%s x%d)", r.Produit, r.Quantite),
+		fmt.Sprintf("Tout raffiner (%d fois → %s x%d)", n, r.Produit, n*r.Quantite),
+		"Annuler",
+	})
+	if cancelled || idx == 2 {
+		return
+	}
+	if idx == 0 {
+		n = 1
+	}
+	payerCout(gs, srcRaffinage, string(r.Produit), multiplierCout(r.Cout, n))
+	// Le four tourne le temps d'une fabrication, quel que soit le volume traité
+	avancerTemps(gs, heuresParFabrication)
+	mouvementMateriau(gs, srcRaffinage, string(r.Produit), r.Produit, n*r.Quantite)
+	fmt.Printf("🔥 Raffinage terminé: %s x%d\n", r.Produit, n*r.Quantite)
+	attendreEntree()
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	"sloteriaa/struct/forgeron"
+)
+
+// Palier accordé par la victoire contre le boss
+const tierBoss = 5
+
+// Chance (en %) de trouver un parchemin de recette après une victoire
+const chanceParchemin = 8
+
+// Entrée du livre de recettes, toutes stations confondues
+type entreeRecette struct {
+	Cle       string
+	Nom       string
+	Categorie string
+	Tier      int
+}
+
+func toutesLesRecettes() []entreeRecette {
+	entrees := []entreeRecette{}
+	for _, r := range forgeron.RecettesArmesHumaines() {
+		entrees = append(entrees, entreeRecette{Cle: r.CleArme, Nom: r.NomAffiche, Categorie: "Arme", Tier: r.Tier})
+	}
+	for _, r := range forgeron.RecettesArmures() {
+		entrees = append(entrees, entreeRecette{Cle: r.CleArmure, Nom: r.NomAffiche, Categorie: "Armure", Tier: r.Tier})
+	}
+	for _, r := range forgeron.RecettesAlchimie() {
+		entrees = append(entrees, entreeRecette{Cle: r.Resultat, Nom: r.NomAffiche, Categorie: "Alchimie", Tier: r.Tier})
+	}
+	return entrees
+}
+
+// recetteConnue indique si la recette est utilisable (connue dès le départ ou débloquée)
+func recetteConnue(gs *GameState, cle string, tier int) bool {
+	return tier == 0 || gs.RecettesDebloquees[cle]
+}
+
+// debloquerRecette ajoute une recette au livre; source décrit l'origine (palier, parchemin, quête...).
+// Retourne false si la recette était déjà connue.
+func debloquerRecette(gs *GameState, cle string, source string) bool {
+	for _, e := range toutesLesRecettes() {
+		if e.Cle != cle {
+			continue
+		}
+		if recetteConnue(gs, e.Cle, e.Tier) {
+			return false
+		}
+		if gs.RecettesDebloquees == nil {
+			gs.RecettesDebloquees = make(map[string]bool)
+		}
+		gs.RecettesDebloquees[cle] = true
+		fmt.Printf("📜 Nouvelle recette (%s) : %s — %s\n", e.Categorie, e.Nom, source)
+		return true
+	}
+	return false
+}
+
+// debloquerRecettesTier débloque les recettes de palier inférieur ou égal à tier
+func debloquerRecettesTier(gs *GameState, tier int) {
+	for _, e := range toutesLesRecettes() {
+		if e.Tier > 0 && e.Tier <= tier {
+			debloquerRecette(gs, e.Cle, fmt.Sprintf("palier %d atteint", tier))
+		}
+	}
+}
+
+// dropParchemin peut révéler une recette verrouillée jusqu'à un palier au-dessus de la salle
+func dropParchemin(gs *GameState, tier int) {
+	if rand.Intn(100) >= chanceParchemin {
+		return
+	}
+	candidates := []string{}
+	for _, e := range toutesLesRecettes() {
+		if e.Tier <= tier+1 && e.Tier != forgeron.TierReserve && !recetteConnue(gs, e.Cle, e.Tier) {
+			candidates = append(candidates, e.Cle)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	fmt.Println("📜 Vous trouvez un parchemin de recette !")
+	debloquerRecette(gs, candidates[rand.Intn(len(candidates))], "parchemin")
+}
+
+// EnterLivreRecettes affiche les recettes connues et verrouillées par catégorie
+func EnterLivreRecettes(gs *GameState) {
+	categories := []string{"Arme", "Armure", "Alchimie"}
+	for {
+		entrees := toutesLesRecettes()
+		opts := make([]string, 0, len(categories)+1)
+		for _, c := range categories {
+			connues, total := 0, 0
+			for _, e := range entrees {
+				if e.Categorie == c {
+					total++
+					if recetteConnue(gs, e.Cle, e.Tier) {
+						connues++
+					}
+				}
+			}
+			opts = append(opts, fmt.Sprintf("%s (%d/%d)", c, connues, total))
+		}
+		opts = append(opts, "Fermer le livre")
+		idx, cancelled := selectWithArrows("Livre de recettes", opts)
+		if cancelled || idx == len(categories) {
+			return
+		}
+		clearScreen()
+		fmt.Printf("Livre de recettes — %s\n\n", categories[idx])
+		for _, e := range entrees {
+			if e.Categorie != categories[idx] {
+				continue
+			}
+			if recetteConnue(gs, e.Cle, e.Tier) {
+				fmt.Printf("  ✅ %s\n", e.Nom)
+			} else if e.Tier == forgeron.TierReserve {
+				fmt.Printf("  🔒 %s — réservée à la Confrérie des forgerons (%s)\n", e.Nom, rangRecetteFaction(e.Cle))
+			} else if e.Tier >= tierBoss {
+				fmt.Printf("  🔒 %s — vaincre le boss ou trouver un parchemin\n", e.Nom)
+			} else {
+				fmt.Printf("  🔒 %s — atteindre le palier %d ou trouver un parchemin\n", e.Nom, e.Tier)
+			}
+		}
+		fmt.Println()
+		attendreEntree()
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+
+	"sloteriaa/struct/forgeron"
 )
 
 const saveFile = "save.json"
@@ -34,9 +36,47 @@ func LoadGame() (*GameState, error) {
 	if err := dec.Decode(&gs); err != nil {
 		return nil, err
 	}
+	migrerSauvegarde(&gs)
 	return &gs, nil
 }
 
+// migrerSauvegarde met à niveau les anciennes sauvegardes:
+// les matériaux de Joueur.Materiaux (noms en minuscules) sont fusionnés dans gs.Mats,
+// et l'ancienne entrée "Or" du registre est abandonnée: c'était une réserve de départ débitée
+// en parallèle de Joueur.Argent, qui fait foi.
+// Les sauvegardes sans livre de recettes reçoivent les recettes des paliers accessibles à leur niveau.
+func migrerSauvegarde(gs *GameState) {
+	// Avant tout mouvement, pour que les totaux reprennent le journal existant
+	migrerJournal(gs)
+	if gs.Mats == nil {
+		gs.Mats = make(forgeron.InventaireMateriaux)
+	}
+	for nom, q := range gs.Joueur.Materiaux {
+		m, ok := forgeron.MateriauDepuisNom(nom)
+		if !ok {
+			m = forgeron.Materiau(nom)
+		}
+		gs.Mats[m] += q
+	}
+	gs.Joueur.Materiaux = nil
+	delete(gs.Mats, forgeron.Or)
+	accepterQuetesPrincipales(gs)
+	if gs.RecettesDebloquees == nil {
+		gs.RecettesDebloquees = make(map[string]bool)
+		for _, e := range toutesLesRecettes() {
+			if e.Tier > 0 && e.Tier < tierBoss && gs.Level >= requiredLevelForTier(e.Tier) {
+				gs.RecettesDebloquees[e.Cle] = true
+			}
+		}
+	}
+	// Recettes de faction des rangs déjà atteints (ajoutées après coup)
+	for _, r := range recettesFaction {
+		if reputationAuMoins(gs, r.Faction, r.Rang) {
+			gs.RecettesDebloquees[r.Cle] = true
+		}
+	}
+}
+
 func DeleteSave() error {
 	if err := os.Remove(saveFile); err != nil {
 		return err
@@ -2,168 +2,363 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"sloteriaa/struct/forgeron"
+	"sloteriaa/struct/objet"
 )
 
-var shopPricesBuy = map[string]int{
-	"potion":           40,  // +20 PV
-	"potion majeure":   80,  // +50 PV
-	"potion force":     60,  // +2 Force temporaire (3 combats)
-	"potion agilite":   60,  // +2 Agilité temporaire (3 combats)
-	"potion endurance": 60,  // +2 Endurance temporaire (3 combats)
-	"antidote":         30,  // Guérit poison/brûlure/saignement
-	"elixir vie":       120, // +100 PV
-}
+// Prix de base: le marché (marche.go) fait varier les prix autour de ces valeurs
+// Les marchands revendent les consommables au double de leur valeur de rachat
+const margeConsommable = 2
 
 var materialPrices = map[forgeron.Materiau]int{
 	forgeron.Fer:            20,
 	forgeron.Bois:           10,
 	forgeron.Cuir:           15,
 	forgeron.EssenceMagique: 100,
+	forgeron.MineraiDeFer:   8,
+	forgeron.PeauBrute:      6,
+	forgeron.CristalBrut:    30,
+}
+
+// Objet vendu pendant la session, rachetable au prix obtenu auprès du même marchand.
+// Le rachat annule les effets de la vente.
+type objetVendu struct {
+	Nom          string
+	Prix         int
+	Marchand     string
+	Exemplaire   *objet.Exemplaire
+	BaseCours    int           // valeur de base du cours que la vente a fait baisser (0: aucun)
+	PointsGuilde int           // points de guilde dus à cet objet dans le lot vendu
+	Quetes       []creditQuete // progression de quête due à la vente
 }
 
-var lootSellPrices = map[string]int{
-	"Griffes souillées": 15,
-	"Massue brute":      20,
-	"Lance brisée":      18,
-	"Épée osseuse":      24,
-	"Hache tronquée":    22,
-	"Glaive sauvage":    26,
-	"Masse rituelle":    28,
-	"Faux de brume":     30,
+// Nombre d'objets gardés par les marchands pour le rachat
+const tailleRachats = 10
+
+// Heures de flânerie au marché par jour: au-delà, le temps ne passe qu'en agissant
+// (sinon attendre sur place suffirait à faire tourner les réapprovisionnements)
+const maxFlanerieParJour = 2
+
+// flaner fait passer une heure au marché, dans la limite quotidienne; false si la limite est atteinte
+func flaner(gs *GameState) bool {
+	jour := gs.Heures / 24
+	if gs.JourFlanerie != jour {
+		gs.JourFlanerie, gs.HeuresFlanees = jour, 0
+	}
+	if gs.HeuresFlanees >= maxFlanerieParJour {
+		return false
+	}
+	gs.HeuresFlanees++
+	avancerTemps(gs, 1)
+	return true
 }
 
+// EnterShop présente les marchands de la ville présents en ce moment
 func EnterShop(gs *GameState) {
 	for {
-		header := fmt.Sprintf("Marché — Or %d", gs.Joueur.Argent)
-		idx, cancelled := selectWithArrows(header, []string{"Acheter matériaux", "Acheter potions", "Vendre objets", "Sortir du marché"})
+		presents := []Marchand{}
+		opts := []string{}
+		for _, m := range marchands() {
+			if !stockMarchand(gs, m).Present {
+				continue
+			}
+			titre, _ := palierReputation(gs, m.Cle)
+			presents = append(presents, m)
+			label := fmt.Sprintf("%s — %s [%s]", m.Nom, m.Description, titre)
+			if !estOuvert(gs, m) {
+				label += fmt.Sprintf(" (fermé, ouvre à %dh)", m.Ouverture)
+			}
+			opts = append(opts, label)
+		}
+		flanerie := "Flâner une heure"
+		if gs.JourFlanerie == gs.Heures/24 {
+			flanerie += fmt.Sprintf(" (%d/%d aujourd'hui)", gs.HeuresFlanees, maxFlanerieParJour)
+		}
+		opts = append(opts, "Cours du marché", flanerie, "Sortir du marché")
+		idx, cancelled := selectWithArrows(fmt.Sprintf("Marché — %s — Or %d", libelleHeure(gs), gs.Joueur.Argent), opts)
 		if cancelled {
 			return
 		}
-		switch idx {
-		case 0:
-			buyMaterials(gs)
-		case 1:
-			buyConsumables(gs)
-		case 2:
-			sellLoot(gs)
-		case 3:
+		switch {
+		case idx < len(presents):
+			m := presents[idx]
+			if !estOuvert(gs, m) {
+				fmt.Printf("%s est fermé. Ouverture à %dh (dans %d h).\n", m.Nom, m.Ouverture, heuresJusqua(gs, m.Ouverture))
+				attendreEntree()
+				continue
+			}
+			EnterMarchand(gs, m)
+		case idx == len(presents):
+			afficherCoursMarche(gs)
+		case idx == len(presents)+1:
+			if !flaner(gs) {
+				fmt.Println("Vous avez assez flâné pour aujourd'hui: partez à l'aventure ou reposez-vous à l'auberge.")
+				attendreEntree()
+			}
+		default:
 			return
 		}
 	}
 }
 
-func buyMaterials(gs *GameState) {
-	keys := []forgeron.Materiau{forgeron.Fer, forgeron.Bois, forgeron.Cuir, forgeron.EssenceMagique}
-	opts := make([]string, 0, len(keys))
-	for _, m := range keys {
-		opts = append(opts, fmt.Sprintf("%s (%d or)", m, materialPrices[m]))
+// EnterMarchand ouvre la boutique d'un marchand: achat, vente selon ses préférences et rachat
+func EnterMarchand(gs *GameState, m Marchand) {
+	for {
+		titre, _ := palierReputation(gs, m.Cle)
+		remise := remiseMarchand(gs, m)
+		header := fmt.Sprintf("%s — Or %d — Réputation: %s (remise %d%%) — Réapprovisionnement dans %d h",
+			m.Nom, gs.Joueur.Argent, titre, remise, stockMarchand(gs, m).AvantReappro)
+		actions := []func(){}
+		opts := []string{}
+		if len(articlesEnVente(gs, m)) > 0 {
+			opts = append(opts, "Acheter")
+			actions = append(actions, func() { acheterArticles(gs, m) })
+		}
+		if len(m.Rachat) > 0 {
+			opts = append(opts, "Vendre objets", fmt.Sprintf("Racheter (%d)", len(rachatsChez(gs, m))))
+			actions = append(actions, func() { sellLoot(gs, m) }, func() { racheterObjet(gs, m) })
+		}
+		opts = append(opts, "Sortir")
+		idx, cancelled := selectWithArrows(header, opts)
+		if cancelled || idx == len(actions) {
+			return
+		}
+		actions[idx]()
+	}
+}
+
+// acheterArticles vend au joueur matériaux et consommables, dans la limite du stock
+func acheterArticles(gs *GameState, m Marchand) {
+	items := articlesEnVente(gs, m)
+	opts := make([]string, 0, len(items))
+	for _, it := range items {
+		base, _ := prixBase(it)
+		opts = append(opts, fmt.Sprintf("%s (%d or, %s — %s)%s", it, prixAchat(gs, m, it), tendance(gs, it, base), libelleStock(gs, m, it), descriptionArticle(it)))
 	}
-	idx, cancelled := selectWithArrows("Matériaux à acheter:", opts)
+	idx, cancelled := selectWithArrows(fmt.Sprintf("%s — Or %d:", m.Nom, gs.Joueur.Argent), opts)
 	if cancelled {
 		return
 	}
-	m := keys[idx]
-	price := materialPrices[m]
-	q := promptQuantity("Quantité:")
-	if q <= 0 {
+	item := items[idx]
+	base, _ := prixBase(item)
+	price := prixAchat(gs, m, item)
+	s := stockMarchand(gs, m)
+	if s.Quantites[item] == 0 {
+		fmt.Printf("%s est épuisé. Réapprovisionnement dans %d h.\n", item, s.AvantReappro)
+		attendreEntree()
+		acheterArticles(gs, m)
 		return
 	}
-	cost := price * q
-	if gs.Joueur.Argent < cost {
+	nMax := maxAchetable(gs, m, item, s.Quantites[item])
+	if nMax == 0 {
 		fmt.Println("Pas assez d'or.")
+		attendreEntree()
+		acheterArticles(gs, m)
 		return
 	}
-	gs.Joueur.Argent -= cost
-	gs.Mats[string(m)] += q
-	fmt.Printf("Acheté %d x %s.\n", q, m)
-	// rester dans le sous-menu matériaux
-	buyMaterials(gs)
+	q, cancelled := saisirQuantite(fmt.Sprintf("%s — dès %d or l'unité, le cours monte de %d%% par unité — Or %d (maximum: %d, %d or)",
+		item, price, variationParUnite, gs.Joueur.Argent, nMax, prixAchatLot(gs, m, item, nMax)), nMax)
+	if cancelled {
+		acheterArticles(gs, m)
+		return
+	}
+	bought := q
+	if _, ok := materialPrices[forgeron.Materiau(item)]; ok {
+		mouvementMateriau(gs, srcAchat, m.Nom, forgeron.Materiau(item), q)
+	} else {
+		// Chaque unité passe par la limite de poids: on ne paie que ce qui rentre
+		bought = 0
+		for bought < q && acquerirObjet(gs, item) {
+			bought++
+		}
+		if bought == 0 {
+			return
+		}
+	}
+	total := prixAchatLot(gs, m, item, bought)
+	mouvementOr(gs, srcAchat, fmt.Sprintf("%d x %s (%s)", bought, item, m.Nom), -total)
+	retirerStock(gs, m, item, bought)
+	enregistrerEchange(gs, item, base, bought, true)
+	fmt.Printf("Acheté %d x %s pour %d or.\n", bought, item, total)
+	gagnerReputation(gs, m.Cle, total)
+	modifierReputation(gs, factionGuilde, total/orParPointGuilde)
+	// rester dans le sous-menu d'achat
+	acheterArticles(gs, m)
 }
 
-func buyConsumables(gs *GameState) {
-	items := []string{"potion", "potion majeure", "potion force", "potion agilite", "potion endurance", "antidote", "elixir vie"}
-	opts := make([]string, 0, len(items))
-	for _, it := range items {
-		label := fmt.Sprintf("%s (%d or)", it, shopPricesBuy[it])
-		// Ajouter la description de chaque potion
-		switch it {
-		case "potion":
-			label += " (+20 PV)"
-		case "potion majeure":
-			label += " (+50 PV)"
-		case "potion force":
-			label += " (+2 Force, 3 combats)"
-		case "potion agilite":
-			label += " (+2 Agilité, 3 combats)"
-		case "potion endurance":
-			label += " (+2 Endurance, 3 combats)"
-		case "antidote":
-			label += " (Guérit statuts)"
-		case "elixir vie":
-			label += " (+100 PV)"
+func sellLoot(gs *GameState, m Marchand) {
+	j := &gs.Joueur
+	noms := []string{}
+	opts := []string{}
+	for _, name := range j.Inventaire {
+		if price, ok := prixVenteChez(gs, m, name); ok {
+			label := fmt.Sprintf("%s (vend %d or)", libelleExemplaire(j, name), price)
+			if estArmeEquipee(j, name) || estArmureEquipee(j, name) {
+				label += "  [Équipé]"
+			}
+			opts = append(opts, label)
+			noms = append(noms, name)
 		}
-		opts = append(opts, label)
 	}
-	idx, cancelled := selectWithArrows("Consommables:", opts)
-	if cancelled {
+	if len(noms) == 0 {
+		fmt.Printf("%s ne s'intéresse à rien dans votre inventaire.\n", m.Nom)
+		fmt.Println("(Appuyez sur Entrée pour revenir)")
+		attendreEntree()
 		return
 	}
-	item := items[idx]
-	price := shopPricesBuy[item]
-	q := promptQuantity("Quantité:")
-	if q <= 0 {
+	choix, cancelled := selectMultipleWithArrows(fmt.Sprintf("Vendre à %s — Or %d (Espace pour cocher):", m.Nom, j.Argent), opts)
+	if cancelled || len(choix) == 0 {
 		return
 	}
-	cost := price * q
-	if gs.Joueur.Argent < cost {
-		fmt.Println("Pas assez d'or.")
-		return
+	// L'équipement porté n'est vendu qu'après confirmation
+	aVendre := []string{}
+	equipes := []string{}
+	for _, i := range choix {
+		if estArmeEquipee(j, noms[i]) || estArmureEquipee(j, noms[i]) {
+			equipes = append(equipes, noms[i])
+		} else {
+			aVendre = append(aVendre, noms[i])
+		}
 	}
-	gs.Joueur.Argent -= cost
-	for i := 0; i < q; i++ {
-		gs.Joueur.Inventaire = append(gs.Joueur.Inventaire, item)
+	if len(equipes) > 0 {
+		idx, annule := selectWithArrows(fmt.Sprintf("Équipement porté sélectionné: %s", strings.Join(equipes, ", ")),
+			[]string{"Vendre aussi l'équipement porté", "Garder l'équipement porté"})
+		if !annule && idx == 0 {
+			aVendre = append(aVendre, equipes...)
+		}
 	}
-	fmt.Printf("Acheté %d x %s.\n", q, item)
-	// rester dans le sous-menu consommables
-	buyConsumables(gs)
+	total := 0
+	for _, name := range aVendre {
+		total += vendreObjet(gs, m, name, total)
+	}
+	if len(aVendre) > 0 {
+		fmt.Printf("Vendu %d objet(s) pour %d or.\n", len(aVendre), total)
+		gagnerReputation(gs, m.Cle, total)
+		modifierReputation(gs, factionGuilde, total/orParPointGuilde)
+		attendreEntree()
+	}
+	// rester dans le sous-menu vente
+	sellLoot(gs, m)
 }
 
-func sellLoot(gs *GameState) {
-	sellableIdx := []int{}
-	opts := []string{}
-	for idx, name := range gs.Joueur.Inventaire {
-		if _, ok := lootSellPrices[name]; ok {
-			opts = append(opts, fmt.Sprintf("%s (vend %d or)", name, lootSellPrices[name]))
-			sellableIdx = append(sellableIdx, idx)
+// vendreObjet vend un exemplaire de l'objet au marchand, le déséquipe s'il n'en reste plus et le garde pour le rachat.
+// dejaVendu est l'or déjà obtenu dans le même lot, pour noter la part de l'objet dans les points de guilde.
+func vendreObjet(gs *GameState, m Marchand, name string, dejaVendu int) int {
+	j := &gs.Joueur
+	price, ok := prixVenteChez(gs, m, name)
+	if !ok || !retirerObjetParNom(j, name) {
+		return 0
+	}
+	vendu := objetVendu{Nom: name, Prix: price, Marchand: m.Cle,
+		PointsGuilde: (dejaVendu+price)/orParPointGuilde - dejaVendu/orParPointGuilde}
+	if ex, ok := exemplaireDe(j, name); ok {
+		vendu.Exemplaire = &ex
+		delete(j.Exemplaires, name)
+	} else if base, _, ok := coursVente(name); ok {
+		enregistrerEchange(gs, name, base, 1, false)
+		vendu.BaseCours = base
+	}
+	if compterObjet(j, name) == 0 {
+		if j.Attaque == name {
+			j.Attaque = ""
 		}
+		delete(j.ArmuresEquipees, name)
 	}
-	if len(sellableIdx) == 0 {
-		fmt.Println("Rien à vendre.")
-		fmt.Println("(Appuyez sur Entrée pour revenir)")
+	mouvementOr(gs, srcVente, fmt.Sprintf("%s (%s)", name, m.Nom), price)
+	vendu.Quetes = signalerQuete(gs, objVendre, objet.NomDeBase(name), 1)
+	gs.Rachats = append(gs.Rachats, vendu)
+	if len(gs.Rachats) > tailleRachats {
+		gs.Rachats = gs.Rachats[len(gs.Rachats)-tailleRachats:]
+	}
+	return price
+}
+
+// rachatsChez retourne les indices (dans gs.Rachats) des objets vendus à ce marchand, du plus récent au plus ancien.
+// Une vente qui a servi à terminer une quête n'est plus rachetable.
+func rachatsChez(gs *GameState, m Marchand) []int {
+	idx := []int{}
+	for i := len(gs.Rachats) - 1; i >= 0; i-- {
+		if gs.Rachats[i].Marchand == m.Cle && !queteTermineePar(gs, gs.Rachats[i].Quetes) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// racheterObjet rend au joueur un objet vendu à ce marchand pendant la session, au prix de vente
+func racheterObjet(gs *GameState, m Marchand) {
+	rachats := rachatsChez(gs, m)
+	if len(rachats) == 0 {
+		fmt.Println("Aucun objet à racheter.")
 		attendreEntree()
 		return
 	}
-	choice, cancelled := selectWithArrows("Vendre:", opts)
+	opts := make([]string, 0, len(rachats))
+	for _, i := range rachats {
+		opts = append(opts, fmt.Sprintf("%s (rachat %d or)", gs.Rachats[i].Nom, gs.Rachats[i].Prix))
+	}
+	idx, cancelled := selectWithArrows(fmt.Sprintf("Racheter — Or %d:", gs.Joueur.Argent), opts)
 	if cancelled {
 		return
 	}
-	invIdx := sellableIdx[choice]
-	name := gs.Joueur.Inventaire[invIdx]
-	price := lootSellPrices[name]
-	gs.Joueur.Inventaire = append(gs.Joueur.Inventaire[:invIdx], gs.Joueur.Inventaire[invIdx+1:]...)
-	gs.Joueur.Argent += price
-	fmt.Printf("Vendu %s pour %d or.\n", name, price)
-	// rester dans le sous-menu vente
-	sellLoot(gs)
+	i := rachats[idx]
+	vendu := gs.Rachats[i]
+	if gs.Joueur.Argent < vendu.Prix {
+		fmt.Println("Pas assez d'or.")
+		attendreEntree()
+		return
+	}
+	if !acquerirObjet(gs, vendu.Nom) {
+		return
+	}
+	if vendu.Exemplaire != nil {
+		if gs.Joueur.Exemplaires == nil {
+			gs.Joueur.Exemplaires = make(map[string]objet.Exemplaire)
+		}
+		gs.Joueur.Exemplaires[vendu.Nom] = *vendu.Exemplaire
+	}
+	mouvementOr(gs, srcRachat, fmt.Sprintf("%s (%s)", vendu.Nom, m.Nom), -vendu.Prix)
+	gagnerReputation(gs, m.Cle, -vendu.Prix)
+	modifierReputation(gs, factionGuilde, -vendu.PointsGuilde)
+	annulerQuete(gs, vendu.Quetes)
+	if vendu.BaseCours > 0 {
+		enregistrerEchange(gs, vendu.Nom, vendu.BaseCours, 1, true)
+	}
+	gs.Rachats = append(gs.Rachats[:i], gs.Rachats[i+1:]...)
+	fmt.Printf("Racheté %s pour %d or.\n", vendu.Nom, vendu.Prix)
+	attendreEntree()
 }
 
-func promptQuantity(label string) int {
-	opts := make([]string, 20)
-	for i := 1; i <= 20; i++ {
-		opts[i-1] = fmt.Sprintf("%s %d", label, i)
+// coursVente retourne la valeur de base d'un objet et la part (en %) de son cours versée à la vente,
+// avant les préférences du marchand:
+// armes, armures et butin au cours plein; les consommables partagent le cours de leur prix d'achat,
+// dont ils rapportent la moitié (leur valeur)
+func coursVente(name string) (int, int, bool) {
+	if c, ok := objet.TrouverConsommable(name); ok {
+		return c.Valeur * margeConsommable, 100 / margeConsommable, true
+	}
+	if a, ok := trouverArmeParNom(name); ok && a.Valeur > 0 {
+		return a.Valeur, 100, true
+	}
+	if a, ok := trouverArmureParNom(name); ok && a.Valeur > 0 {
+		return a.Valeur, 100, true
+	}
+	if a, ok := trouverArmeMonstreParNom(name); ok && a.Valeur > 0 {
+		return a.Valeur, 100, true
+	}
+	return 0, 0, false
+}
+
+// prixVente retourne le prix de rachat d'un objet: exemplaire unique à prix fixe, sinon selon le cours du marché
+func prixVente(gs *GameState, name string) (int, bool) {
+	if ex, ok := exemplaireDe(&gs.Joueur, name); ok {
+		return prixVenteExemplaire(ex), true
+	}
+	base, pct, ok := coursVente(name)
+	if !ok {
+		return 0, false
 	}
-	idx, _ := selectWithArrows("", opts)
-	return idx + 1
+	return max(1, prixMarche(gs, name, base)*pct/100), true
 }
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Stock d'un marchand: unités restantes par article et rotation des articles rares
+type StockMarchand struct {
+	Quantites    map[string]int
+	Rares        map[string]bool // articles rares présents jusqu'au prochain réapprovisionnement
+	Present      bool            // le marchand tient boutique jusqu'au prochain réapprovisionnement
+	AvantReappro int             // heures de jeu restantes avant le réapprovisionnement
+}
+
+// Heures de jeu entre deux réapprovisionnements (trois expéditions)
+const heuresReappro = 3 * heuresParExpedition
+
+// stockMarchand retourne le stock d'un marchand, garni au premier accès
+func stockMarchand(gs *GameState, m Marchand) *StockMarchand {
+	if gs.Stocks == nil {
+		gs.Stocks = make(map[string]*StockMarchand)
+	}
+	s, ok := gs.Stocks[m.Cle]
+	if !ok {
+		s = &StockMarchand{}
+		reapprovisionner(s, m)
+		gs.Stocks[m.Cle] = s
+	}
+	return s
+}
+
+// reapprovisionner remplit le stock, tire la rotation d'articles rares et la présence du marchand
+func reapprovisionner(s *StockMarchand, m Marchand) {
+	s.Quantites = make(map[string]int)
+	for _, a := range m.Articles {
+		s.Quantites[a.Nom] = a.Max
+	}
+	s.Rares = make(map[string]bool)
+	for _, r := range m.Rares {
+		if rand.Intn(100) < r.Chance {
+			s.Rares[r.Nom] = true
+			s.Quantites[r.Nom] = r.Max
+		}
+	}
+	s.Present = m.ChancePresence == 0 || rand.Intn(100) < m.ChancePresence
+	// Un marchand sans article permanent vient toujours avec au moins une rareté
+	if s.Present && len(m.Articles) == 0 && len(s.Rares) == 0 && len(m.Rares) > 0 {
+		r := m.Rares[rand.Intn(len(m.Rares))]
+		s.Rares[r.Nom] = true
+		s.Quantites[r.Nom] = r.Max
+	}
+	s.AvantReappro = heuresReappro
+}
+
+// avancerStock décompte le temps écoulé et réapprovisionne les marchands à échéance
+func avancerStock(gs *GameState, heures int) {
+	reappro := false
+	for _, m := range marchands() {
+		s := stockMarchand(gs, m)
+		s.AvantReappro -= heures
+		if s.AvantReappro <= 0 {
+			reapprovisionner(s, m)
+			reappro = true
+		}
+	}
+	if reappro {
+		fmt.Println("🛒 Les marchands ont été réapprovisionnés.")
+	}
+}
+
+// articlesEnVente liste les articles proposés actuellement: permanents puis rares de la rotation
+func articlesEnVente(gs *GameState, m Marchand) []string {
+	s := stockMarchand(gs, m)
+	items := []string{}
+	for _, a := range m.Articles {
+		items = append(items, a.Nom)
+	}
+	for _, r := range m.Rares {
+		if s.Rares[r.Nom] {
+			items = append(items, r.Nom)
+		}
+	}
+	return items
+}
+
+func retirerStock(gs *GameState, m Marchand, nom string, q int) {
+	s := stockMarchand(gs, m)
+	s.Quantites[nom] -= q
+	if s.Quantites[nom] < 0 {
+		s.Quantites[nom] = 0
+	}
+}
+
+// libelleStock décrit le stock d'un article pour les menus d'achat
+func libelleStock(gs *GameState, m Marchand, nom string) string {
+	s := stockMarchand(gs, m)
+	label := fmt.Sprintf("stock %d", s.Quantites[nom])
+	if s.Quantites[nom] == 0 {
+		label = "épuisé"
+	}
+	if s.Rares[nom] {
+		label = "✦ rare, " + label
+	}
+	return label
+}
@@ -0,0 +1,197 @@
+package forgeron
+
+import (
+	"errors"
+	"sort"
+
+	"sloteriaa/struct/objet"
+)
+
+// Atelier expose à l'interface de forge l'état du joueur: or, matériaux, inventaire et poids.
+// Le jeu fournit son propre atelier; AtelierSimple sert aux outils autonomes.
+type Atelier interface {
+	Materiaux() InventaireMateriaux
+	OrDisponible() int
+	PoidsActuel() int
+	PoidsMax() int
+	// RecetteConnue filtre les recettes verrouillées (tier 0 = toujours connue)
+	RecetteConnue(cle string, tier int) bool
+	// Équipement porté, pour comparer les statistiques
+	ArmeEquipee() (objet.Arme, bool)
+	ArmureEquipee(typ objet.TypeArmure) (objet.Armure, bool)
+	// CoutRecette retourne le coût réellement payé pour une recette (variante de fabrication du jeu)
+	CoutRecette(c Cout) Cout
+	// Fabriquer débite le coût et ajoute l'objet à l'inventaire; retourne le nom obtenu
+	Fabriquer(cle string, armure bool, cout Cout) (string, error)
+}
+
+var (
+	ErrRessources = errors.New("ressources insuffisantes")
+	ErrTropLourd  = errors.New("inventaire trop lourd")
+)
+
+// PeutPayer vérifie les matériaux et l'or d'un coût (l'or est compté à part des matériaux)
+func PeutPayer(a Atelier, c Cout) bool {
+	inv := a.Materiaux()
+	for m, q := range c {
+		if m == Or {
+			if a.OrDisponible() < q {
+				return false
+			}
+		} else if inv[m] < q {
+			return false
+		}
+	}
+	return true
+}
+
+// AtelierSimple: atelier en mémoire (matériaux, or, liste d'objets), sans qualité ni recettes verrouillées
+type AtelierSimple struct {
+	Inv      InventaireMateriaux
+	Argent   int
+	Objets   []string
+	Capacite int // poids maximal (0 = illimité)
+}
+
+func (s *AtelierSimple) Materiaux() InventaireMateriaux { return s.Inv }
+func (s *AtelierSimple) OrDisponible() int              { return s.Argent }
+func (s *AtelierSimple) PoidsMax() int                  { return s.Capacite }
+
+func (s *AtelierSimple) PoidsActuel() int {
+	total := 0
+	for _, o := range s.Objets {
+		total += poidsObjetBase(o)
+	}
+	return total
+}
+
+func (s *AtelierSimple) RecetteConnue(cle string, tier int) bool { return true }
+func (s *AtelierSimple) CoutRecette(c Cout) Cout                 { return c }
+func (s *AtelierSimple) ArmeEquipee() (objet.Arme, bool)         { return objet.Arme{}, false }
+func (s *AtelierSimple) ArmureEquipee(typ objet.TypeArmure) (objet.Armure, bool) {
+	return objet.Armure{}, false
+}
+
+func (s *AtelierSimple) Fabriquer(cle string, armure bool, cout Cout) (string, error) {
+	if !PeutPayer(s, cout) {
+		return "", ErrRessources
+	}
+	nom, poids := objet.CreerArme(cle).Nom, objet.CreerArme(cle).Poids
+	if armure {
+		nom, poids = objet.CreerArmure(cle).Nom, objet.CreerArmure(cle).Poids
+	}
+	if s.Capacite > 0 && s.PoidsActuel()+poids > s.Capacite {
+		return "", ErrTropLourd
+	}
+	for m, q := range cout {
+		if m == Or {
+			s.Argent -= q
+		}
+	}
+	s.Inv.Debiter(cout)
+	delete(s.Inv, Or)
+	s.Objets = append(s.Objets, nom)
+	return nom, nil
+}
+
+func poidsObjetBase(nom string) int {
+	for _, r := range RecettesArmesHumaines() {
+		if a := objet.CreerArme(r.CleArme); a.Nom == nom {
+			return a.Poids
+		}
+	}
+	for _, r := range RecettesArmures() {
+		if a := objet.CreerArmure(r.CleArmure); a.Nom == nom {
+			return a.Poids
+		}
+	}
+	return 1
+}
+
+// Ligne affichée dans la liste de la forge (arme ou armure)
+type ligneForge struct {
+	Cle         string
+	Nom         string
+	Armure      bool
+	Type        objet.TypeArmure
+	Attaque     int
+	Defense     int
+	Poids       int
+	Description string
+	Cout        Cout
+}
+
+// lignesForge construit la liste des recettes connues, filtrée et triée
+func lignesForge(a Atelier, armures bool, tri SortMode, fabricables bool) []ligneForge {
+	lignes := []ligneForge{}
+	if armures {
+		for _, r := range RecettesArmures() {
+			if !a.RecetteConnue(r.CleArmure, r.Tier) {
+				continue
+			}
+			ar := objet.CreerArmure(r.CleArmure)
+			lignes = append(lignes, ligneForge{Cle: r.CleArmure, Nom: r.NomAffiche, Armure: true, Type: ar.Type, Defense: ar.EffetDefense, Poids: ar.Poids, Cout: a.CoutRecette(r.Cout)})
+		}
+	} else {
+		for _, r := range RecettesArmesHumaines() {
+			if !a.RecetteConnue(r.CleArme, r.Tier) {
+				continue
+			}
+			ar := objet.CreerArme(r.CleArme)
+			lignes = append(lignes, ligneForge{Cle: r.CleArme, Nom: r.NomAffiche, Attaque: ar.EffetAttaque, Poids: ar.Poids, Description: ar.Description, Cout: a.CoutRecette(r.Cout)})
+		}
+	}
+	if fabricables {
+		filtrees := lignes[:0]
+		for _, l := range lignes {
+			if PeutPayer(a, l.Cout) {
+				filtrees = append(filtrees, l)
+			}
+		}
+		lignes = filtrees
+	}
+	sort.SliceStable(lignes, func(i, j int) bool {
+		switch tri {
+		case SortByAttack:
+			return lignes[i].Attaque > lignes[j].Attaque
+		case SortByDefense:
+			return lignes[i].Defense > lignes[j].Defense
+		case SortByWeight:
+			return lignes[i].Poids < lignes[j].Poids
+		case SortByCost:
+			return lignes[i].Cout[Or] < lignes[j].Cout[Or] ||
+				lignes[i].Cout[Or] == lignes[j].Cout[Or] && totalCost(lignes[i].Cout) < totalCost(lignes[j].Cout)
+		}
+		return false
+	})
+	return lignes
+}
+
+// triSuivant fait défiler les modes de tri pertinents pour la liste affichée
+func triSuivant(tri SortMode, armures bool) SortMode {
+	modes := []SortMode{SortNone, SortByAttack, SortByWeight, SortByCost}
+	if armures {
+		modes = []SortMode{SortNone, SortByDefense, SortByWeight, SortByCost}
+	}
+	for i, m := range modes {
+		if m == tri {
+			return modes[(i+1)%len(modes)]
+		}
+	}
+	return SortNone
+}
+
+func libelleTri(tri SortMode) string {
+	switch tri {
+	case SortByAttack:
+		return "attaque"
+	case SortByDefense:
+		return "défense"
+	case SortByWeight:
+		return "poids"
+	case SortByCost:
+		return "coût"
+	default:
+		return "aucun"
+	}
+}
@@ -2,6 +2,7 @@ package forgeron
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -40,16 +41,104 @@ const (
 	Cuir           Materiau = "Cuir tanné"
 	EssenceMagique Materiau = "Essence magique"
 	Or             Materiau = "Or"
+
+	// Matériaux trouvés dans le donjon
+	Pierre           Materiau = "Pierre"
+	CuirRenforce     Materiau = "Cuir renforcé"
+	BoisDur          Materiau = "Bois dur"
+	FerRenforce      Materiau = "Fer renforcé"
+	PierrePrecieuse  Materiau = "Pierre précieuse"
+	OsAncien         Materiau = "Os ancien"
+	Gemme            Materiau = "Gemme"
+	Ecailles         Materiau = "Écailles"
+	GemmeDePouvoir   Materiau = "Gemme de pouvoir"
+	CristalDeMana    Materiau = "Cristal de mana"
+	EcaillesDeDragon Materiau = "Écailles de dragon"
+
+	// Matériaux bruts, à raffiner à la forge
+	MineraiDeFer Materiau = "Minerai de fer"
+	Buche        Materiau = "Bûche"
+	PeauBrute    Materiau = "Peau brute"
+	CristalBrut  Materiau = "Cristal brut"
 )
 
+// Anciens noms de butin (en minuscules) vers les matériaux typés
+var aliasMateriaux = map[string]Materiau{
+	"fer":   Fer,
+	"bois":  Bois,
+	"cuir":  Cuir,
+	"or":    Or,
+	"gemme": Gemme,
+}
+
+// MateriauDepuisNom retrouve un matériau à partir de son nom affiché ou d'un ancien nom de butin
+func MateriauDepuisNom(nom string) (Materiau, bool) {
+	n := strings.ToLower(strings.TrimSpace(nom))
+	if m, ok := aliasMateriaux[n]; ok {
+		return m, true
+	}
+	for _, m := range OrdreMateriaux {
+		if strings.ToLower(string(m)) == n {
+			return m, true
+		}
+	}
+	return "", false
+}
+
 // Coût d'une recette par matériau
 type Cout map[Materiau]int
 
+// Variante de fabrication débloquée par la compétence de forge
+type VarianteRecette struct {
+	Nom           string
+	NiveauRequis  int
+	ReductionMats int  // % de matériaux économisés (au moins 1 de chaque reste nécessaire)
+	SupplementOr  int  // % d'or en plus
+	Supplement    Cout // matériaux ajoutés au coût
+	QualiteMin    objet.Qualite
+}
+
+// Variantes proposées pour chaque recette d'arme ou d'armure
+func VariantesRecette() []VarianteRecette {
+	return []VarianteRecette{
+		{Nom: "Standard"},
+		{Nom: "Économe", NiveauRequis: 3, ReductionMats: 25},
+		{Nom: "Trempe soignée", NiveauRequis: 5, SupplementOr: 50, QualiteMin: objet.Normale},
+		{Nom: "Œuvre de maître", NiveauRequis: 8, SupplementOr: 100, Supplement: Cout{EssenceMagique: 1}, QualiteMin: objet.Superieure},
+	}
+}
+
+// Appliquer retourne le coût d'une recette modifié par la variante
+func (v VarianteRecette) Appliquer(c Cout) Cout {
+	res := Cout{}
+	for m, q := range c {
+		switch {
+		case m == Or:
+			q += q * v.SupplementOr / 100
+		case v.ReductionMats > 0:
+			q -= q * v.ReductionMats / 100
+			if q < 1 {
+				q = 1
+			}
+		}
+		res[m] = q
+	}
+	for m, q := range v.Supplement {
+		res[m] += q
+	}
+	return res
+}
+
+// Palier des recettes réservées: jamais débloquées par palier ni par parchemin,
+// seulement par la réputation auprès de la Confrérie des forgerons
+const TierReserve = 100
+
 // Recette d'artisanat d'une arme humaine (non-monstre)
 type Recette struct {
 	CleArme    string
 	NomAffiche string
 	Cout       Cout
+	Tier       int // palier du donjon qui débloque la recette (0 = connue dès le départ)
 }
 
 // Recette pour une armure
@@ -57,6 +146,7 @@ type RecetteArmure struct {
 	CleArmure  string
 	NomAffiche string
 	Cout       Cout
+	Tier       int // palier du donjon qui débloque la recette (0 = connue dès le départ)
 }
 
 // Inventaire des matériaux du joueur
@@ -81,11 +171,16 @@ func (inv InventaireMateriaux) Debiter(c Cout) {
 }
 
 // Ordre d'affichage déterministe des matériaux
-var materialOrder = []Materiau{Or, Fer, Bois, Cuir, EssenceMagique}
+var OrdreMateriaux = []Materiau{
+	Or, Fer, Bois, Cuir, EssenceMagique,
+	Pierre, CuirRenforce, BoisDur, FerRenforce, PierrePrecieuse, OsAncien,
+	Gemme, Ecailles, GemmeDePouvoir, CristalDeMana, EcaillesDeDragon,
+	MineraiDeFer, Buche, PeauBrute, CristalBrut,
+}
 
 func orderedKeysFromCout(c Cout) []Materiau {
 	ordered := make([]Materiau, 0, len(c))
-	for _, m := range materialOrder {
+	for _, m := range OrdreMateriaux {
 		if _, ok := c[m]; ok {
 			ordered = append(ordered, m)
 		}
@@ -98,14 +193,18 @@ func RecettesArmesHumaines() []Recette {
 	return []Recette{
 		{CleArme: "EpeeRouillee", NomAffiche: "Épée rouillée", Cout: Cout{Fer: 1, Or: 100}},
 		{CleArme: "EpeeCourte", NomAffiche: "Épée courte", Cout: Cout{Fer: 2, Cuir: 1, Or: 180}},
-		{CleArme: "EpeeFer", NomAffiche: "Épée en fer", Cout: Cout{Fer: 4, Cuir: 1, Or: 260}},
-		{CleArme: "Hache", NomAffiche: "Hache lourde", Cout: Cout{Fer: 5, Bois: 2, Or: 300}},
-		{CleArme: "HacheDeCombat", NomAffiche: "Hache de combat", Cout: Cout{Fer: 4, Bois: 2, Cuir: 1, Or: 380}},
-		{CleArme: "HacheDeBataille", NomAffiche: "Hache de bataille", Cout: Cout{Fer: 7, Bois: 3, Or: 700}},
+		{CleArme: "EpeeFer", NomAffiche: "Épée en fer", Cout: Cout{Fer: 4, Cuir: 1, Pierre: 1, Or: 260}, Tier: 1},
+		{CleArme: "Hache", NomAffiche: "Hache lourde", Cout: Cout{Fer: 5, Bois: 2, Or: 300}, Tier: 2},
+		{CleArme: "HacheDeCombat", NomAffiche: "Hache de combat", Cout: Cout{Fer: 4, Bois: 1, BoisDur: 1, Cuir: 1, Or: 380}, Tier: 2},
+		{CleArme: "HacheDeBataille", NomAffiche: "Hache de bataille", Cout: Cout{Fer: 6, FerRenforce: 1, BoisDur: 3, Or: 700}, Tier: 4},
 		{CleArme: "ArcBois", NomAffiche: "Arc en bois", Cout: Cout{Bois: 4, Cuir: 1, Or: 120}},
-		{CleArme: "ArcLong", NomAffiche: "Arc long", Cout: Cout{Bois: 6, Cuir: 2, Or: 220}},
-		{CleArme: "ArcElfe", NomAffiche: "Arc elfique", Cout: Cout{Bois: 5, Cuir: 2, EssenceMagique: 1, Or: 450}},
-		{CleArme: "EpeeMagique", NomAffiche: "Épée magique", Cout: Cout{Fer: 5, EssenceMagique: 2, Or: 950}},
+		{CleArme: "ArcLong", NomAffiche: "Arc long", Cout: Cout{Bois: 4, BoisDur: 2, Cuir: 2, Or: 220}, Tier: 1},
+		{CleArme: "ArcElfe", NomAffiche: "Arc elfique", Cout: Cout{BoisDur: 5, CuirRenforce: 2, EssenceMagique: 1, Or: 450}, Tier: 3},
+		{CleArme: "EpeeMagique", NomAffiche: "Épée magique", Cout: Cout{Fer: 4, FerRenforce: 1, EssenceMagique: 2, Gemme: 1, Or: 950}, Tier: 5},
+
+		// Réservées à la Confrérie des forgerons
+		{CleArme: "HacheDeLaConfrerie", NomAffiche: "Hache de la Confrérie", Cout: Cout{Fer: 5, FerRenforce: 2, BoisDur: 3, Or: 780}, Tier: TierReserve},
+		{CleArme: "EpeeDuMaitreForgeron", NomAffiche: "Épée du maître forgeron", Cout: Cout{Fer: 3, FerRenforce: 3, EssenceMagique: 2, Gemme: 2, Or: 1200}, Tier: TierReserve},
 	}
 }
 
@@ -114,30 +213,83 @@ func RecettesArmures() []RecetteArmure {
 	return []RecetteArmure{
 		// Casques
 		{CleArmure: "CasqueCuir", NomAffiche: "Casque en cuir", Cout: Cout{Cuir: 2, Or: 120}},
-		{CleArmure: "CasqueCuirRenforce", NomAffiche: "Casque cuir renforcé", Cout: Cout{Cuir: 3, Fer: 1, Or: 180}},
-		{CleArmure: "CasqueFer", NomAffiche: "Casque en fer", Cout: Cout{Fer: 3, Or: 260}},
-		{CleArmure: "CasqueFerRenforce", NomAffiche: "Casque fer renforcé", Cout: Cout{Fer: 4, Cuir: 1, Or: 340}},
+		{CleArmure: "CasqueCuirRenforce", NomAffiche: "Casque cuir renforcé", Cout: Cout{Cuir: 2, CuirRenforce: 1, Fer: 1, Or: 180}, Tier: 1},
+		{CleArmure: "CasqueFer", NomAffiche: "Casque en fer", Cout: Cout{Fer: 3, Or: 260}, Tier: 2},
+		{CleArmure: "CasqueFerRenforce", NomAffiche: "Casque fer renforcé", Cout: Cout{Fer: 3, FerRenforce: 1, Cuir: 1, Or: 340}, Tier: 3},
 
 		// Plastrons
 		{CleArmure: "PlastronCuir", NomAffiche: "Plastron cuir", Cout: Cout{Cuir: 3, Or: 180}},
-		{CleArmure: "PlastronCuirRenforce", NomAffiche: "Plastron cuir renforcé", Cout: Cout{Cuir: 4, Fer: 1, Or: 260}},
-		{CleArmure: "PlastronFer", NomAffiche: "Plastron fer", Cout: Cout{Fer: 5, Or: 420}},
-		{CleArmure: "PlastronFerRenforce", NomAffiche: "Plastron fer renforcé", Cout: Cout{Fer: 6, Cuir: 1, Or: 800}},
+		{CleArmure: "PlastronCuirRenforce", NomAffiche: "Plastron cuir renforcé", Cout: Cout{Cuir: 2, CuirRenforce: 2, Fer: 1, Or: 260}, Tier: 1},
+		{CleArmure: "PlastronFer", NomAffiche: "Plastron fer", Cout: Cout{Fer: 5, Or: 420}, Tier: 2},
+		{CleArmure: "PlastronFerRenforce", NomAffiche: "Plastron fer renforcé", Cout: Cout{Fer: 4, FerRenforce: 2, Ecailles: 1, Cuir: 1, Or: 800}, Tier: 4},
+		{CleArmure: "PlastronDeLaConfrerie", NomAffiche: "Plastron de la Confrérie", Cout: Cout{Fer: 3, FerRenforce: 3, Ecailles: 2, Cuir: 1, Or: 950}, Tier: TierReserve},
 
 		// Pantalons
 		{CleArmure: "PantalonCuir", NomAffiche: "Pantalon cuir", Cout: Cout{Cuir: 2, Or: 150}},
-		{CleArmure: "PantalonCuirRenforce", NomAffiche: "Pantalon cuir renforcé", Cout: Cout{Cuir: 3, Fer: 1, Or: 220}},
-		{CleArmure: "PantalonFer", NomAffiche: "Pantalon fer", Cout: Cout{Fer: 4, Or: 320}},
-		{CleArmure: "PantalonFerRenforce", NomAffiche: "Pantalon fer renforcé", Cout: Cout{Fer: 5, Cuir: 1, Or: 450}},
+		{CleArmure: "PantalonCuirRenforce", NomAffiche: "Pantalon cuir renforcé", Cout: Cout{Cuir: 2, CuirRenforce: 1, Fer: 1, Or: 220}, Tier: 1},
+		{CleArmure: "PantalonFer", NomAffiche: "Pantalon fer", Cout: Cout{Fer: 4, Or: 320}, Tier: 2},
+		{CleArmure: "PantalonFerRenforce", NomAffiche: "Pantalon fer renforcé", Cout: Cout{Fer: 4, FerRenforce: 1, Cuir: 1, Or: 450}, Tier: 3},
 
 		// Chaussures
 		{CleArmure: "BottesCuir", NomAffiche: "Bottes cuir", Cout: Cout{Cuir: 2, Or: 120}},
-		{CleArmure: "BottesCuirRenforce", NomAffiche: "Bottes cuir renforcé", Cout: Cout{Cuir: 3, Fer: 1, Or: 180}},
-		{CleArmure: "BottesFer", NomAffiche: "Bottes fer", Cout: Cout{Fer: 3, Or: 240}},
-		{CleArmure: "BottesFerRenforce", NomAffiche: "Bottes fer renforcé", Cout: Cout{Fer: 4, Cuir: 1, Or: 320}},
+		{CleArmure: "BottesCuirRenforce", NomAffiche: "Bottes cuir renforcé", Cout: Cout{Cuir: 2, CuirRenforce: 1, Fer: 1, Or: 180}, Tier: 1},
+		{CleArmure: "BottesFer", NomAffiche: "Bottes fer", Cout: Cout{Fer: 3, Or: 240}, Tier: 2},
+		{CleArmure: "BottesFerRenforce", NomAffiche: "Bottes fer renforcé", Cout: Cout{Fer: 3, FerRenforce: 1, Cuir: 1, Or: 320}, Tier: 3},
+	}
+}
+
+// Recette d'alchimie: produit Quantite exemplaires d'un consommable
+type RecetteAlchimie struct {
+	Resultat   string // nom du consommable dans l'inventaire
+	NomAffiche string
+	Quantite   int
+	Cout       Cout
+	Tier       int // palier du donjon qui débloque la recette (0 = connue dès le départ)
+}
+
+// Catalogue des recettes d'alchimie
+func RecettesAlchimie() []RecetteAlchimie {
+	return []RecetteAlchimie{
+		{Resultat: "potion", NomAffiche: "Potion", Quantite: 2, Cout: Cout{Bois: 1, Pierre: 1, Or: 10}},
+		{Resultat: "potion majeure", NomAffiche: "Potion majeure", Quantite: 1, Cout: Cout{OsAncien: 1, Bois: 1, Or: 20}},
+		{Resultat: "antidote", NomAffiche: "Antidote", Quantite: 2, Cout: Cout{Pierre: 1, Cuir: 1, Or: 10}},
+		{Resultat: "elixir vie", NomAffiche: "Élixir de vie", Quantite: 1, Cout: Cout{CristalDeMana: 1, OsAncien: 1, Or: 40}, Tier: 2},
+		{Resultat: "potion force", NomAffiche: "Potion de force", Quantite: 1, Cout: Cout{OsAncien: 1, Fer: 1, Or: 20}, Tier: 1},
+		{Resultat: "potion agilite", NomAffiche: "Potion d'agilité", Quantite: 1, Cout: Cout{Ecailles: 1, Bois: 1, Or: 20}, Tier: 1},
+		{Resultat: "potion endurance", NomAffiche: "Potion d'endurance", Quantite: 1, Cout: Cout{Ecailles: 1, Cuir: 1, Or: 20}, Tier: 1},
+		{Resultat: "potion resistance feu", NomAffiche: "Potion de résistance au feu", Quantite: 1, Cout: Cout{Ecailles: 2, Pierre: 1, Or: 30}, Tier: 3},
+		{Resultat: "potion purification", NomAffiche: "Potion de purification", Quantite: 1, Cout: Cout{CristalDeMana: 1, PierrePrecieuse: 1, Or: 30}, Tier: 2},
+		{Resultat: "potion regeneration", NomAffiche: "Potion de régénération", Quantite: 1, Cout: Cout{OsAncien: 2, Gemme: 1, Or: 30}, Tier: 3},
+	}
+}
+
+// Recette de raffinage: Cout (matériau brut et frais en or) donne Quantite du produit fini
+type RecetteRaffinage struct {
+	Produit  Materiau
+	Quantite int
+	Cout     Cout
+}
+
+// Catalogue des recettes de raffinage
+func RecettesRaffinage() []RecetteRaffinage {
+	return []RecetteRaffinage{
+		{Produit: Fer, Quantite: 1, Cout: Cout{MineraiDeFer: 3, Or: 5}},
+		{Produit: Bois, Quantite: 2, Cout: Cout{Buche: 1, Or: 2}},
+		{Produit: Cuir, Quantite: 1, Cout: Cout{PeauBrute: 2, Or: 4}},
+		{Produit: EssenceMagique, Quantite: 1, Cout: Cout{CristalBrut: 3, Or: 15}},
 	}
 }
 
+// EstBrut indique si un matériau doit être raffiné avant d'être utilisé
+func EstBrut(m Materiau) bool {
+	for _, r := range RecettesRaffinage() {
+		if _, ok := r.Cout[m]; ok && m != Or {
+			return true
+		}
+	}
+	return false
+}
+
 // Interface console: point d'entrée de la forge
 func RunForge(inv InventaireMateriaux) {
 	reader := bufio.NewReader(os.Stdin)
@@ -201,7 +353,7 @@ func afficherInventaire(inv InventaireMateriaux) {
 		return
 	}
 	fmt.Println("Inventaire matériaux:")
-	for _, m := range materialOrder {
+	for _, m := range OrdreMateriaux {
 		if q, ok := inv[m]; ok && q > 0 {
 			fmt.Printf("  - %s x%d\n", m, q)
 		}
@@ -251,23 +403,6 @@ func exitAltScreen()  { fmt.Print("\033[?1049l") }
 func hideCursor() { fmt.Print("\033[?25l") }
 func showCursor() { fmt.Print("\033[?25h") }
 
-// Renders one full frame: UI + controls + optional modal message
-func renderForgeTUIFrame(recettes []Recette, inv InventaireMateriaux, selection int, showArmors bool, modal string) {
-	// Reposition cursor to top-left and redraw frame without full reset to reduce flicker
-	fmt.Print("\033[H")
-	renderForgeTUI(recettes, inv, selection, showArmors)
-	// Controls line
-	fmt.Printf("%sContrôles:%s ↑/↓ naviguer | C forger | T armes/armures | Q quitter\n", ansiCyan, ansiReset)
-	// Modal message
-	if modal != "" {
-		fmt.Println("--------------------------------------------------")
-		fmt.Println(modal)
-		fmt.Println("(Appuyez sur une touche pour revenir)")
-	}
-	// Clear any remaining content below the current cursor position
-	fmt.Print("\033[J")
-}
-
 func splitCostMaterialsGold(c Cout) (materials []string, gold int) {
 	materials = []string{}
 	for _, m := range orderedKeysFromCout(c) {
@@ -281,7 +416,7 @@ func splitCostMaterialsGold(c Cout) (materials []string, gold int) {
 }
 
 // Build a full frame into a single string to minimize I/O and flicker
-func renderFrameString(recettes []Recette, inv InventaireMateriaux, selection int, showArmors bool, modal string) string {
+func renderFrameString(a Atelier, lignes []ligneForge, selection int, showArmors bool, tri SortMode, fabricables bool, modal string) string {
 	var b strings.Builder
 	w, _ := termSize()
 	if runtime.GOOS == "windows" {
@@ -315,10 +450,15 @@ func renderFrameString(recettes []Recette, inv InventaireMateriaux, selection in
 		rightInner = 10
 	}
 
-	left := []string{"Inventaire matériaux:"}
+	poids := fmt.Sprintf("Poids: %d", a.PoidsActuel())
+	if a.PoidsMax() > 0 {
+		poids += fmt.Sprintf("/%d", a.PoidsMax())
+	}
+	left := []string{fmt.Sprintf("Or: %s | %s", colorYellow(fmt.Sprintf("%d", a.OrDisponible())), poids), "", "Inventaire matériaux:"}
 	printed := false
-	for _, m := range materialOrder {
-		if q, ok := inv[m]; ok && q > 0 {
+	inv := a.Materiaux()
+	for _, m := range OrdreMateriaux {
+		if q, ok := inv[m]; ok && q > 0 && m != Or {
 			left = append(left, fmt.Sprintf("- %s x%d", m, q))
 			printed = true
 		}
@@ -328,113 +468,67 @@ func renderFrameString(recettes []Recette, inv InventaireMateriaux, selection in
 	}
 	left = append(left, "")
 
+	liste := "Liste: Armes"
 	if showArmors {
-		left = append(left, "Liste: Armures")
-		ars := RecettesArmures()
-		if len(ars) == 0 {
-			left = append(left, "(aucune recette)")
-		} else {
-			if selection >= len(ars) {
-				selection = 0
-			}
-			for i, r := range ars {
-				chev := ' '
-				if i == selection {
-					chev = '>'
-				}
-				line := fmt.Sprintf("%c %2d) %s", chev, i+1, r.NomAffiche)
-				if i == selection {
-					line = ansiBgWhite + ansiBlack + line + ansiReset
-				}
-				left = append(left, line)
-			}
+		liste = "Liste: Armures"
+	}
+	liste += " | Tri: " + libelleTri(tri)
+	if fabricables {
+		liste += " | Fabricables"
+	}
+	left = append(left, liste)
+	if len(lignes) == 0 {
+		left = append(left, "(aucune recette)")
+	}
+	for i, l := range lignes {
+		chev := ' '
+		if i == selection {
+			chev = '>'
 		}
-	} else {
-		left = append(left, "Liste: Armes")
-		if len(recettes) == 0 {
-			left = append(left, "(aucune recette)")
-		} else {
-			if selection >= len(recettes) {
-				selection = 0
-			}
-			for i, r := range recettes {
-				chev := ' '
-				if i == selection {
-					chev = '>'
-				}
-				line := fmt.Sprintf("%c %2d) %s", chev, i+1, r.NomAffiche)
-				if i == selection {
-					line = ansiBgWhite + ansiBlack + line + ansiReset
-				}
-				left = append(left, line)
-			}
+		line := fmt.Sprintf("%c %2d) %s", chev, i+1, l.Nom)
+		if i == selection {
+			line = ansiBgWhite + ansiBlack + line + ansiReset
 		}
+		left = append(left, line)
 	}
 	leftBox := boxify("Forge", left, leftInner)
 
 	right := []string{"Détails:"}
-	if showArmors {
-		ars := RecettesArmures()
-		if len(ars) > 0 {
-			if selection >= len(ars) {
-				selection = 0
-			}
-			ch := ars[selection]
-			arm := objet.CreerArmure(ch.CleArmure)
+	if selection < len(lignes) {
+		l := lignes[selection]
+		right = append(right, fmt.Sprintf("Nom: %s", l.Nom))
+		if l.Armure {
 			right = append(right,
-				fmt.Sprintf("Nom: %s", arm.Nom),
-				fmt.Sprintf("Type: %s", arm.Type),
-				fmt.Sprintf("Défense: %d", arm.EffetDefense),
-				fmt.Sprintf("Poids: %d", arm.Poids),
-				"Coût (matériaux):")
-			mats, gold := splitCostMaterialsGold(ch.Cout)
-			if len(mats) > 0 {
-				right = append(right, strings.Join(mats, ", "))
-			} else {
-				right = append(right, "-")
-			}
-			if inv.AAssez(ch.Cout) {
-				right = append(right, "[Disponible]")
-			} else {
-				right = append(right, "[Matériaux insuffisants]")
-			}
-			// Bottom-right gold cost inside right panel
-			goldLine := fmt.Sprintf("Coût: %s", colorYellow(fmt.Sprintf("%d", gold)))
-			right = append(right, padLeftANSI(goldLine, rightInner))
+				fmt.Sprintf("Type: %s", l.Type),
+				fmt.Sprintf("Défense: %d", l.Defense),
+				fmt.Sprintf("Poids: %d", l.Poids))
 		} else {
-			right = append(right, "(sélectionnez une armure)")
-		}
-	} else {
-		if len(recettes) > 0 {
-			if selection >= len(recettes) {
-				selection = 0
-			}
-			ch := recettes[selection]
-			arme := objet.CreerArme(ch.CleArme)
 			right = append(right,
-				fmt.Sprintf("Nom: %s", arme.Nom),
-				fmt.Sprintf("Attaque: %d", arme.EffetAttaque),
-				fmt.Sprintf("Poids: %d", arme.Poids),
-				"Description:")
-			right = append(right, arme.Description)
-			right = append(right, "Coût (matériaux):")
-			mats, gold := splitCostMaterialsGold(ch.Cout)
-			if len(mats) > 0 {
-				right = append(right, strings.Join(mats, ", "))
-			} else {
-				right = append(right, "-")
-			}
-			if inv.AAssez(ch.Cout) {
-				right = append(right, "[Disponible]")
-			} else {
-				right = append(right, "[Matériaux insuffisants]")
-			}
-			// Bottom-right gold cost inside right panel
-			goldLine := fmt.Sprintf("Coût: %s", colorYellow(fmt.Sprintf("%d", gold)))
-			right = append(right, padLeftANSI(goldLine, rightInner))
+				fmt.Sprintf("Attaque: %d", l.Attaque),
+				fmt.Sprintf("Poids: %d", l.Poids),
+				"Description:", l.Description)
+		}
+		right = append(right, "", "Comparaison:")
+		right = append(right, comparaisonEquipement(a, l)...)
+		right = append(right, "", "Coût (matériaux):")
+		mats, gold := splitCostMaterialsGold(l.Cout)
+		if len(mats) > 0 {
+			right = append(right, strings.Join(mats, ", "))
 		} else {
-			right = append(right, "(sélectionnez une arme)")
+			right = append(right, "-")
 		}
+		if PeutPayer(a, l.Cout) {
+			right = append(right, "[Disponible]")
+		} else {
+			right = append(right, "[Ressources insuffisantes]")
+		}
+		// Bottom-right gold cost inside right panel
+		goldLine := fmt.Sprintf("Coût: %s", colorYellow(fmt.Sprintf("%d", gold)))
+		right = append(right, padLeftANSI(goldLine, rightInner))
+	} else if showArmors {
+		right = append(right, "(sélectionnez une armure)")
+	} else {
+		right = append(right, "(sélectionnez une arme)")
 	}
 	rightBox := boxify("Panneau", right, rightInner)
 
@@ -459,7 +553,7 @@ func renderFrameString(recettes []Recette, inv InventaireMateriaux, selection in
 	}
 
 	// Controls
-	fmt.Fprintf(&b, "%sContrôles:%s ↑/↓ naviguer | C forger | T armes/armures | Q quitter\n", ansiCyan, ansiReset)
+	fmt.Fprintf(&b, "%sContrôles:%s ↑/↓ naviguer | C forger | T armes/armures | O trier | F fabricables | Q quitter\n", ansiCyan, ansiReset)
 	// Modal
 	if modal != "" {
 		b.WriteString("--------------------------------------------------\n")
@@ -470,6 +564,51 @@ func renderFrameString(recettes []Recette, inv InventaireMateriaux, selection in
 	return b.String()
 }
 
+// comparaisonEquipement compare la recette sélectionnée avec l'équipement porté
+func comparaisonEquipement(a Atelier, l ligneForge) []string {
+	var porte string
+	var actuel, nouveau int
+	stat := "ATK"
+	if l.Armure {
+		stat = "DEF"
+		nouveau = l.Defense
+		if ar, ok := a.ArmureEquipee(l.Type); ok {
+			porte, actuel = ar.Nom, ar.EffetDefense
+		}
+	} else {
+		nouveau = l.Attaque
+		if ar, ok := a.ArmeEquipee(); ok {
+			porte, actuel = ar.Nom, ar.EffetAttaque
+		}
+	}
+	if porte == "" {
+		porte = "(rien)"
+	}
+	ecart := fmt.Sprintf("%+d %s", nouveau-actuel, stat)
+	switch {
+	case nouveau > actuel:
+		ecart = ansiGreen + ecart + ansiReset
+	case nouveau < actuel:
+		ecart = ansiRed + ecart + ansiReset
+	}
+	return []string{fmt.Sprintf("Équipé: %s (%s %d)", porte, stat, actuel), "Écart: " + ecart}
+}
+
+// fabriquerLigne tente la fabrication et retourne le message à afficher
+func fabriquerLigne(a Atelier, l ligneForge) string {
+	nom, err := a.Fabriquer(l.Cle, l.Armure, l.Cout)
+	switch {
+	case errors.Is(err, ErrTropLourd):
+		return "Inventaire trop lourd pour porter cet objet."
+	case err != nil:
+		return "Ressources insuffisantes pour fabriquer cet objet."
+	}
+	if l.Armure {
+		return fmt.Sprintf("Fabrication réussie !\nNom: %s\nType: %s\nDéfense: %d | Poids: %d", nom, l.Type, l.Defense, l.Poids)
+	}
+	return fmt.Sprintf("Fabrication réussie !\nNom: %s\nAttaque: %d | Poids: %d", nom, l.Attaque, l.Poids)
+}
+
 // Rune-aware helpers
 func runeCount(s string) int {
 	return utf8.RuneCountInString(s)
@@ -704,207 +843,23 @@ func totalCost(c Cout) int {
 	return sum
 }
 
-// Render without ANSI inside borders; highlight selection with colored line
-func renderForgeTUI(recettes []Recette, inv InventaireMateriaux, selection int, showArmors bool) {
-	// Do not full-clear here; caller positions cursor at home before render
-	w, _ := termSize()
-	if runtime.GOOS == "windows" {
-		w = 120
-	}
-	if w < 60 {
-		w = 60
-	}
-	fmt.Printf("%s=== Forge (TUI) ===%s\n", ansiCyan, ansiReset)
-
-	gap := 2
-	available := w - gap
-	leftTotal := (available * 40) / 100
-	if leftTotal < 26 {
-		leftTotal = 26
-	}
-	rightTotal := available - leftTotal
-	if rightTotal < 30 {
-		rightTotal = 30
-		leftTotal = available - rightTotal
-		if leftTotal < 26 {
-			leftTotal = 26
-		}
-	}
-	leftInner := leftTotal - 2
-	rightInner := rightTotal - 2
-	if leftInner < 10 {
-		leftInner = 10
-	}
-	if rightInner < 10 {
-		rightInner = 10
-	}
-
-	left := []string{"Inventaire matériaux:"}
-	printed := false
-	for _, m := range materialOrder {
-		if q, ok := inv[m]; ok && q > 0 {
-			left = append(left, fmt.Sprintf("- %s x%d", m, q))
-			printed = true
-		}
-	}
-	if !printed {
-		left = append(left, "(vide)")
-	}
-	left = append(left, "")
-
-	if showArmors {
-		left = append(left, "Liste: Armures")
-		ars := RecettesArmures()
-		if len(ars) == 0 {
-			left = append(left, "(aucune recette)")
-		} else {
-			if selection >= len(ars) {
-				selection = 0
-			}
-			for i, r := range ars {
-				chev := ' '
-				if i == selection {
-					chev = '>'
-				}
-				line := fmt.Sprintf("%c %2d) %s", chev, i+1, r.NomAffiche)
-				if i == selection {
-					line = ansiBgWhite + ansiBlack + line + ansiReset
-				}
-				left = append(left, line)
-			}
-		}
-	} else {
-		left = append(left, "Liste: Armes")
-		if len(recettes) == 0 {
-			left = append(left, "(aucune recette)")
-		} else {
-			if selection >= len(recettes) {
-				selection = 0
-			}
-			for i, r := range recettes {
-				chev := ' '
-				if i == selection {
-					chev = '>'
-				}
-				line := fmt.Sprintf("%c %2d) %s", chev, i+1, r.NomAffiche)
-				if i == selection {
-					line = ansiBgWhite + ansiBlack + line + ansiReset
-				}
-				left = append(left, line)
-			}
-		}
-	}
-	leftBox := boxify("Forge", left, leftInner)
-
-	right := []string{"Détails:"}
-	if showArmors {
-		ars := RecettesArmures()
-		if len(ars) > 0 {
-			if selection >= len(ars) {
-				selection = 0
-			}
-			ch := ars[selection]
-			arm := objet.CreerArmure(ch.CleArmure)
-			right = append(right,
-				fmt.Sprintf("Nom: %s", arm.Nom),
-				fmt.Sprintf("Type: %s", arm.Type),
-				fmt.Sprintf("Défense: %d", arm.EffetDefense),
-				fmt.Sprintf("Poids: %d", arm.Poids),
-				"Coût:")
-			costLine := strings.Join(func() []string {
-				a := []string{}
-				for _, m := range orderedKeysFromCout(ch.Cout) {
-					a = append(a, fmt.Sprintf("%s x%d", m, ch.Cout[m]))
-				}
-				return a
-			}(), ", ")
-			right = append(right, costLine)
-			if inv.AAssez(ch.Cout) {
-				right = append(right, "[Disponible]")
-			} else {
-				right = append(right, "[Matériaux insuffisants]")
-			}
-			// Bottom-right gold cost inside right panel
-			_, gold := splitCostMaterialsGold(ch.Cout)
-			goldLine := fmt.Sprintf("Coût: %s", colorYellow(fmt.Sprintf("%d", gold)))
-			right = append(right, padLeftANSI(goldLine, rightInner))
-		} else {
-			right = append(right, "(sélectionnez une armure)")
-		}
-	} else {
-		if len(recettes) > 0 {
-			if selection >= len(recettes) {
-				selection = 0
-			}
-			ch := recettes[selection]
-			arme := objet.CreerArme(ch.CleArme)
-			right = append(right,
-				fmt.Sprintf("Nom: %s", arme.Nom),
-				fmt.Sprintf("Attaque: %d", arme.EffetAttaque),
-				fmt.Sprintf("Poids: %d", arme.Poids),
-				"Description:")
-			right = append(right, arme.Description)
-			right = append(right, "Coût:")
-			costLine := strings.Join(func() []string {
-				a := []string{}
-				for _, m := range orderedKeysFromCout(ch.Cout) {
-					a = append(a, fmt.Sprintf("%s x%d", m, ch.Cout[m]))
-				}
-				return a
-			}(), ", ")
-			right = append(right, costLine)
-			if inv.AAssez(ch.Cout) {
-				right = append(right, "[Disponible]")
-			} else {
-				right = append(right, "[Matériaux insuffisants]")
-			}
-			// Bottom-right gold cost inside right panel
-			_, gold := splitCostMaterialsGold(ch.Cout)
-			goldLine := fmt.Sprintf("Coût: %s", colorYellow(fmt.Sprintf("%d", gold)))
-			right = append(right, padLeftANSI(goldLine, rightInner))
-		} else {
-			right = append(right, "(sélectionnez une arme)")
-		}
-	}
-	rightBox := boxify("Panneau", right, rightInner)
-
-	leftBox, rightBox = equalizeBoxHeights(leftBox, rightBox, leftInner, rightInner)
-	maxLines := len(leftBox)
-	if len(rightBox) > maxLines {
-		maxLines = len(rightBox)
-	}
-	for i := 0; i < maxLines; i++ {
-		l := padRightANSI("", leftTotal)
-		r := padRightANSI("", rightTotal)
-		if i < len(leftBox) {
-			l = padRightANSI(leftBox[i], leftTotal)
-		}
-		if i < len(rightBox) {
-			r = padRightANSI(rightBox[i], rightTotal)
-		}
-		fmt.Print(l)
-		fmt.Print(repeatRune(' ', gap))
-		fmt.Println(r)
-	}
-}
-
 // -------- TUI améliorée (navigation clavier) --------
 
-func RunForgeTUI(inv InventaireMateriaux) {
+func RunForgeTUI(a Atelier) {
 	enableWindowsVT()
-	recettes := RecettesArmesHumaines()
-	if len(recettes) == 0 {
-		fmt.Println("Aucune recette disponible.")
-		return
-	}
-
 	enterAltScreen()
 	defer exitAltScreen()
 	reader := bufio.NewReader(os.Stdin)
 	selection := 0
 	showArmors := false
+	tri := SortNone
+	fabricables := false
 	for {
-		frame := renderFrameString(recettes, inv, selection, showArmors, "")
+		lignes := lignesForge(a, showArmors, tri, fabricables)
+		if selection >= len(lignes) {
+			selection = 0
+		}
+		frame := renderFrameString(a, lignes, selection, showArmors, tri, fabricables, "")
 		// Home only, then write frame
 		fmt.Print("\033[H")
 		fmt.Print(frame)
@@ -918,65 +873,28 @@ func RunForgeTUI(inv InventaireMateriaux) {
 				selection--
 			}
 		case "s":
-			max := len(recettes)
-			if showArmors {
-				max = len(RecettesArmures())
-			}
-			if selection < max-1 {
+			if selection < len(lignes)-1 {
 				selection++
 			}
 		case "t":
 			showArmors = !showArmors
+			tri = SortNone
+			selection = 0
+		case "o":
+			tri = triSuivant(tri, showArmors)
+			selection = 0
+		case "f":
+			fabricables = !fabricables
 			selection = 0
 		case "c":
-			if showArmors {
-				ars := RecettesArmures()
-				if len(ars) == 0 {
-					continue
-				}
-				if selection >= len(ars) {
-					selection = 0
-				}
-				choisie := ars[selection]
-				if !inv.AAssez(choisie.Cout) {
-					modal := "Matériaux insuffisants pour fabriquer cette armure."
-					fmt.Print("\033[H")
-					fmt.Print(renderFrameString(recettes, inv, selection, showArmors, modal))
-					fmt.Print("\033[J")
-					reader.ReadString('\n')
-					continue
-				}
-				inv.Debiter(choisie.Cout)
-				arm := objet.CreerArmure(choisie.CleArmure)
-				modal := fmt.Sprintf("Fabrication réussie !\nNom: %s\nType: %s\nDéfense: %d | Poids: %d", arm.Nom, arm.Type, arm.EffetDefense, arm.Poids)
-				fmt.Print("\033[H")
-				fmt.Print(renderFrameString(recettes, inv, selection, showArmors, modal))
-				fmt.Print("\033[J")
-				reader.ReadString('\n')
-			} else {
-				if len(recettes) == 0 {
-					continue
-				}
-				if selection >= len(recettes) {
-					selection = 0
-				}
-				choisie := recettes[selection]
-				if !inv.AAssez(choisie.Cout) {
-					modal := "Matériaux insuffisants pour fabriquer cette arme."
-					fmt.Print("\033[H")
-					fmt.Print(renderFrameString(recettes, inv, selection, showArmors, modal))
-					fmt.Print("\033[J")
-					reader.ReadString('\n')
-					continue
-				}
-				inv.Debiter(choisie.Cout)
-				arme := objet.CreerArme(choisie.CleArme)
-				modal := fmt.Sprintf("Fabrication réussie !\nNom: %s\nAttaque: %d | Poids: %d", arme.Nom, arme.EffetAttaque, arme.Poids)
-				fmt.Print("\033[H")
-				fmt.Print(renderFrameString(recettes, inv, selection, showArmors, modal))
-				fmt.Print("\033[J")
-				reader.ReadString('\n')
+			if len(lignes) == 0 {
+				continue
 			}
+			modal := fabriquerLigne(a, lignes[selection])
+			fmt.Print("\033[H")
+			fmt.Print(renderFrameString(a, lignesForge(a, showArmors, tri, fabricables), selection, showArmors, tri, fabricables, modal))
+			fmt.Print("\033[J")
+			reader.ReadString('\n')
 		case "q":
 			resetTerminalVisual()
 			return
@@ -986,14 +904,9 @@ func RunForgeTUI(inv InventaireMateriaux) {
 
 // -------- TUI temps réel avec flèches (sans Entrée) --------
 
-// RunForgeInteractive lit les touches en temps réel (flèches, c, q) via go-tty
-func RunForgeInteractive(inv InventaireMateriaux) {
+// RunForgeInteractive lit les touches en temps réel (flèches, c, t, o, f, q) via go-tty
+func RunForgeInteractive(a Atelier) {
 	enableWindowsVT()
-	recettes := RecettesArmesHumaines()
-	if len(recettes) == 0 {
-		fmt.Println("Aucune recette disponible.")
-		return
-	}
 	t, err := tty.Open()
 	if err != nil {
 		fmt.Println("Impossible d'initialiser le TTY:", err)
@@ -1006,9 +919,15 @@ func RunForgeInteractive(inv InventaireMateriaux) {
 	defer showCursor()
 	selection := 0
 	showArmors := false
+	tri := SortNone
+	fabricables := false
 	modal := ""
 	for {
-		frame := renderFrameString(recettes, inv, selection, showArmors, modal)
+		lignes := lignesForge(a, showArmors, tri, fabricables)
+		if selection >= len(lignes) {
+			selection = 0
+		}
+		frame := renderFrameString(a, lignes, selection, showArmors, tri, fabricables, modal)
 		fmt.Print("\033[H")
 		fmt.Print(frame)
 		fmt.Print("\033[J")
@@ -1024,49 +943,24 @@ func RunForgeInteractive(inv InventaireMateriaux) {
 				selection--
 			}
 		case "down":
-			max := len(recettes)
-			if showArmors {
-				max = len(RecettesArmures())
-			}
-			if selection < max-1 {
+			if selection < len(lignes)-1 {
 				selection++
 			}
 		case "t":
 			showArmors = !showArmors
+			tri = SortNone
+			selection = 0
+		case "o":
+			tri = triSuivant(tri, showArmors)
+			selection = 0
+		case "f":
+			fabricables = !fabricables
 			selection = 0
 		case "c":
-			if showArmors {
-				ars := RecettesArmures()
-				if len(ars) == 0 {
-					continue
-				}
-				if selection >= len(ars) {
-					selection = 0
-				}
-				choisie := ars[selection]
-				if !inv.AAssez(choisie.Cout) {
-					modal = "Matériaux insuffisants pour fabriquer cette armure."
-					continue
-				}
-				inv.Debiter(choisie.Cout)
-				arm := objet.CreerArmure(choisie.CleArmure)
-				modal = fmt.Sprintf("Fabrication réussie !\nNom: %s\nType: %s\nDéfense: %d | Poids: %d", arm.Nom, arm.Type, arm.EffetDefense, arm.Poids)
-			} else {
-				if len(recettes) == 0 {
-					continue
-				}
-				if selection >= len(recettes) {
-					selection = 0
-				}
-				choisie := recettes[selection]
-				if !inv.AAssez(choisie.Cout) {
-					modal = "Matériaux insuffisants pour fabriquer cette arme."
-					continue
-				}
-				inv.Debiter(choisie.Cout)
-				arme := objet.CreerArme(choisie.CleArme)
-				modal = fmt.Sprintf("Fabrication réussie !\nNom: %s\nAttaque: %d | Poids: %d", arme.Nom, arme.EffetAttaque, arme.Poids)
+			if len(lignes) == 0 {
+				continue
 			}
+			modal = fabriquerLigne(a, lignes[selection])
 		case "q":
 			resetTerminalVisual()
 			return
@@ -1100,7 +994,7 @@ func readKey(t *tty.TTY) string {
 	}
 	// lettres de commande
 	s := strings.ToLower(string(r))
-	if s == "c" || s == "q" || s == "t" {
+	if s == "c" || s == "q" || s == "t" || s == "o" || s == "f" {
 		return s
 	}
 	return ""
@@ -12,6 +12,7 @@ type Arme struct {
 	Type         TypeObjet
 	EffetAttaque int
 	Poids        int
+	Valeur       int // prix de rachat de base chez le marchand
 }
 
 // Arme uniquement utilisable par les monstres, avec des stats uniques
@@ -23,6 +24,7 @@ type ArmeMonstre struct {
 	Poids        int
 	Instabilite  int // Variance potentielle des dégâts (0-10)
 	Sauvagerie   int // Brutalité/saignement potentiel (0-10)
+	Valeur       int // prix de rachat de base chez le marchand
 }
 
 // Création d'une arme selon son nom
@@ -30,33 +32,37 @@ func CreerArme(nom string) Arme {
 	switch nom {
 	// Épées
 	case "EpeeRouillee":
-		return Arme{"Épée rouillée", "Vieille épée peu puissante", TypeArme, 15, 5}
+		return Arme{"Épée rouillée", "Vieille épée peu puissante", TypeArme, 15, 5, 25}
 	case "EpeeFer":
-		return Arme{"Épée en fer", "Épée solide et fiable", TypeArme, 35, 8}
+		return Arme{"Épée en fer", "Épée solide et fiable", TypeArme, 35, 8, 65}
 	case "EpeeMagique":
-		return Arme{"Épée magique", "Épée enchantée par la magie ancienne", TypeArme, 60, 6}
+		return Arme{"Épée magique", "Épée enchantée par la magie ancienne", TypeArme, 60, 6, 240}
 	case "EpeeCourte":
-		return Arme{"Épée courte", "Épée rapide et maniable", TypeArme, 30, 4}
+		return Arme{"Épée courte", "Épée rapide et maniable", TypeArme, 30, 4, 45}
+	case "EpeeDuMaitreForgeron":
+		return Arme{"Épée du maître forgeron", "Acier feuilleté que seuls les maîtres de la Confrérie savent forger", TypeArme, 68, 6, 340}
 
 	// Haches
 	case "Hache":
-		return Arme{"Hache lourde", "Hache massive et lourde", TypeArme, 40, 10}
+		return Arme{"Hache lourde", "Hache massive et lourde", TypeArme, 40, 10, 75}
 	case "HacheDeCombat":
-		return Arme{"Hache de combat", "Hache équilibrée pour le combat", TypeArme, 35, 8}
+		return Arme{"Hache de combat", "Hache équilibrée pour le combat", TypeArme, 35, 8, 95}
 	case "HacheDeBataille":
-		return Arme{"Hache de bataille", "Hache puissante à deux mains", TypeArme, 50, 12}
+		return Arme{"Hache de bataille", "Hache puissante à deux mains", TypeArme, 50, 12, 175}
+	case "HacheDeLaConfrerie":
+		return Arme{"Hache de la Confrérie", "Hache frappée du sceau de la Confrérie des forgerons", TypeArme, 58, 11, 260}
 
 	// Arcs
 	case "ArcBois":
-		return Arme{"Arc en bois", "Arc simple pour attaques à distance", TypeArme, 25, 3}
+		return Arme{"Arc en bois", "Arc simple pour attaques à distance", TypeArme, 25, 3, 30}
 	case "ArcLong":
-		return Arme{"Arc long", "Arc puissant et précis", TypeArme, 35, 4}
+		return Arme{"Arc long", "Arc puissant et précis", TypeArme, 35, 4, 55}
 	case "ArcElfe":
-		return Arme{"Arc elfique", "Arc léger et rapide, très précis", TypeArme, 40, 3}
+		return Arme{"Arc elfique", "Arc léger et rapide, très précis", TypeArme, 40, 3, 110}
 
 	default:
 		fmt.Println("Arme inconnue, création d'une épée rouillée par défaut")
-		return Arme{"Épée rouillée", "Vieille épée peu puissante", TypeArme, 15, 5}
+		return Arme{"Épée rouillée", "Vieille épée peu puissante", TypeArme, 15, 5, 25}
 	}
 }
 
@@ -65,29 +71,29 @@ func CreerArmeMonstre(nom string) ArmeMonstre {
 	switch nom {
 	// Armes bas niveau (1-2)
 	case "GriffesSouillees":
-		return ArmeMonstre{"Griffes souillées", "Griffes acérées couvertes d'impuretés", TypeArme, 18, 0, 3, 4}
+		return ArmeMonstre{"Griffes souillées", "Griffes acérées couvertes d'impuretés", TypeArme, 18, 0, 3, 4, 15}
 	case "MassueBrute":
-		return ArmeMonstre{"Massue brute", "Gros morceau de bois noueux", TypeArme, 22, 9, 2, 4}
+		return ArmeMonstre{"Massue brute", "Gros morceau de bois noueux", TypeArme, 22, 9, 2, 4, 20}
 
 	// Milieu de progression (3-6)
 	case "LanceBrisee":
-		return ArmeMonstre{"Lance brisée", "Lance ébréchée ramassée sur un champ de bataille", TypeArme, 26, 5, 4, 3}
+		return ArmeMonstre{"Lance brisée", "Lance ébréchée ramassée sur un champ de bataille", TypeArme, 26, 5, 4, 3, 18}
 	case "EpeeOsseuse":
-		return ArmeMonstre{"Épée osseuse", "Lame en os taillé, instable mais mordante", TypeArme, 32, 6, 5, 5}
+		return ArmeMonstre{"Épée osseuse", "Lame en os taillé, instable mais mordante", TypeArme, 32, 6, 5, 5, 24}
 	case "HacheTronquee":
-		return ArmeMonstre{"Hache tronquée", "Hache abîmée mais très lourde", TypeArme, 36, 11, 3, 6}
+		return ArmeMonstre{"Hache tronquée", "Hache abîmée mais très lourde", TypeArme, 36, 11, 3, 6, 22}
 
 	// Haut niveaux (7-10), inférieures aux meilleures armes du joueur
 	case "GlaiveSauvage":
-		return ArmeMonstre{"Glaive sauvage", "Glaive brut reforgé à la hâte", TypeArme, 38, 8, 6, 6}
+		return ArmeMonstre{"Glaive sauvage", "Glaive brut reforgé à la hâte", TypeArme, 38, 8, 6, 6, 26}
 	case "MasseRituelle":
-		return ArmeMonstre{"Masse rituelle", "Masse gravée de symboles inquiétants", TypeArme, 42, 12, 4, 7}
+		return ArmeMonstre{"Masse rituelle", "Masse gravée de symboles inquiétants", TypeArme, 42, 12, 4, 7, 28}
 	case "FauxDeBrume":
-		return ArmeMonstre{"Faux de brume", "Lame incurvée difficile à prévoir", TypeArme, 44, 7, 7, 6}
+		return ArmeMonstre{"Faux de brume", "Lame incurvée difficile à prévoir", TypeArme, 44, 7, 7, 6, 30}
 
 	default:
 		fmt.Println("Arme de monstre inconnue, création de Griffes souillées par défaut")
-		return ArmeMonstre{"Griffes souillées", "Griffes acérées couvertes d'impuretés", TypeArme, 18, 0, 3, 4}
+		return ArmeMonstre{"Griffes souillées", "Griffes acérées couvertes d'impuretés", TypeArme, 18, 0, 3, 4, 15}
 	}
 }
 
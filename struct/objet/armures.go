@@ -17,6 +17,7 @@ type Armure struct {
 	Type         TypeArmure
 	EffetDefense int
 	Poids        int
+	Valeur       int // prix de rachat de base chez le marchand
 }
 
 // Création d'une armure selon son nom
@@ -24,47 +25,49 @@ func CreerArmure(nom string) Armure {
 	switch nom {
 	// Casques
 	case "CasqueCuir":
-		return Armure{"Casque en cuir", "Casque léger en cuir", TypeCasque, 5, 2}
+		return Armure{"Casque en cuir", "Casque léger en cuir", TypeCasque, 5, 2, 30}
 	case "CasqueCuirRenforce":
-		return Armure{"Casque en cuir renforcé", "Casque plus résistant", TypeCasque, 8, 3}
+		return Armure{"Casque en cuir renforcé", "Casque plus résistant", TypeCasque, 8, 3, 45}
 	case "CasqueFer":
-		return Armure{"Casque en fer", "Casque solide en fer", TypeCasque, 15, 5}
+		return Armure{"Casque en fer", "Casque solide en fer", TypeCasque, 15, 5, 65}
 	case "CasqueFerRenforce":
-		return Armure{"Casque en fer renforcé", "Casque très solide", TypeCasque, 20, 6}
+		return Armure{"Casque en fer renforcé", "Casque très solide", TypeCasque, 20, 6, 85}
 
 	// Plastrons
 	case "PlastronCuir":
-		return Armure{"Plastron en cuir", "Protection souple pour le torse", TypePlastron, 10, 5}
+		return Armure{"Plastron en cuir", "Protection souple pour le torse", TypePlastron, 10, 5, 45}
 	case "PlastronCuirRenforce":
-		return Armure{"Plastron en cuir renforcé", "Plastron plus résistant", TypePlastron, 15, 6}
+		return Armure{"Plastron en cuir renforcé", "Plastron plus résistant", TypePlastron, 15, 6, 65}
 	case "PlastronFer":
-		return Armure{"Plastron en fer", "Plastron métallique solide", TypePlastron, 30, 12}
+		return Armure{"Plastron en fer", "Plastron métallique solide", TypePlastron, 30, 12, 105}
 	case "PlastronFerRenforce":
-		return Armure{"Plastron en fer renforcé", "Plastron très solide", TypePlastron, 40, 14}
+		return Armure{"Plastron en fer renforcé", "Plastron très solide", TypePlastron, 40, 14, 200}
+	case "PlastronDeLaConfrerie":
+		return Armure{"Plastron de la Confrérie", "Plastron trempé selon les secrets de la Confrérie", TypePlastron, 48, 13, 280}
 
 	// Pantalons
 	case "PantalonCuir":
-		return Armure{"Pantalon en cuir", "Pantalon léger offrant une protection modérée", TypePantalon, 8, 3}
+		return Armure{"Pantalon en cuir", "Pantalon léger offrant une protection modérée", TypePantalon, 8, 3, 38}
 	case "PantalonCuirRenforce":
-		return Armure{"Pantalon en cuir renforcé", "Pantalon plus résistant", TypePantalon, 12, 4}
+		return Armure{"Pantalon en cuir renforcé", "Pantalon plus résistant", TypePantalon, 12, 4, 55}
 	case "PantalonFer":
-		return Armure{"Pantalon en fer", "Pantalon blindé", TypePantalon, 20, 8}
+		return Armure{"Pantalon en fer", "Pantalon blindé", TypePantalon, 20, 8, 80}
 	case "PantalonFerRenforce":
-		return Armure{"Pantalon en fer renforcé", "Pantalon très solide", TypePantalon, 25, 10}
+		return Armure{"Pantalon en fer renforcé", "Pantalon très solide", TypePantalon, 25, 10, 110}
 
 	// Chaussures
 	case "BottesCuir":
-		return Armure{"Bottes en cuir", "Bottes légères offrant un minimum de protection", TypeChaussure, 5, 2}
+		return Armure{"Bottes en cuir", "Bottes légères offrant un minimum de protection", TypeChaussure, 5, 2, 30}
 	case "BottesCuirRenforce":
-		return Armure{"Bottes en cuir renforcé", "Bottes plus résistantes", TypeChaussure, 8, 3}
+		return Armure{"Bottes en cuir renforcé", "Bottes plus résistantes", TypeChaussure, 8, 3, 45}
 	case "BottesFer":
-		return Armure{"Bottes en fer", "Bottes solides en fer", TypeChaussure, 15, 5}
+		return Armure{"Bottes en fer", "Bottes solides en fer", TypeChaussure, 15, 5, 60}
 	case "BottesFerRenforce":
-		return Armure{"Bottes en fer renforcé", "Bottes très solides", TypeChaussure, 20, 6}
+		return Armure{"Bottes en fer renforcé", "Bottes très solides", TypeChaussure, 20, 6, 80}
 
 	default:
 		fmt.Println("Armure inconnue, création d'un casque en cuir par défaut")
-		return Armure{"Casque en cuir", "Casque léger en cuir", TypeCasque, 5, 2}
+		return Armure{"Casque en cuir", "Casque léger en cuir", TypeCasque, 5, 2, 30}
 	}
 }
 
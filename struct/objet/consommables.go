@@ -0,0 +1,41 @@
+package objet
+
+import "strings"
+
+const TypeConsommable TypeObjet = "Consommable"
+
+// Consommable: potion, élixir ou parchemin, rangé dans l'inventaire sous son nom
+type Consommable struct {
+	Nom         string
+	Description string
+	Type        TypeObjet
+	Poids       int
+	Valeur      int // prix de rachat de base chez le marchand
+}
+
+// Consommables retourne le catalogue des consommables (achetés, brassés ou trouvés)
+func Consommables() []Consommable {
+	return []Consommable{
+		{"potion", "+20 PV", TypeConsommable, 1, 20},
+		{"potion majeure", "+50 PV", TypeConsommable, 1, 40},
+		{"potion force", "+2 Force, 3 combats", TypeConsommable, 1, 30},
+		{"potion agilite", "+2 Agilité, 3 combats", TypeConsommable, 1, 30},
+		{"potion endurance", "+2 Endurance, 3 combats", TypeConsommable, 1, 30},
+		{"antidote", "Guérit statuts", TypeConsommable, 1, 15},
+		{"elixir vie", "+100 PV", TypeConsommable, 1, 60},
+		{"potion resistance feu", "Immunité brûlure, 3 combats", TypeConsommable, 1, 55},
+		{"potion purification", "Guérit et immunise, 2 combats", TypeConsommable, 1, 75},
+		{"potion regeneration", "+8 PV/tour, 10 tours", TypeConsommable, 1, 70},
+		{"parchemin protection", "Évite la perte de niveau d'amélioration", TypeConsommable, 1, 125},
+	}
+}
+
+// TrouverConsommable retrouve un consommable par son nom (insensible à la casse)
+func TrouverConsommable(nom string) (Consommable, bool) {
+	for _, c := range Consommables() {
+		if strings.EqualFold(c.Nom, strings.TrimSpace(nom)) {
+			return c, true
+		}
+	}
+	return Consommable{}, false
+}
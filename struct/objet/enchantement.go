@@ -0,0 +1,87 @@
+package objet
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Famille d'enchantement posée à la table d'enchantement
+type TypeEnchantement string
+
+const (
+	EnchantElementaire TypeEnchantement = "élémentaire" // dégâts supplémentaires à chaque coup
+	EnchantStatut      TypeEnchantement = "statut"      // chance d'infliger un statut au toucher
+	EnchantProtection  TypeEnchantement = "protection"  // réduit les dégâts d'un type de monstre
+)
+
+// Cible: élément (feu, glace, foudre), type de statut (stun, poison, burn, bleed) ou type de monstre.
+// Valeur: dégâts bonus, chance en % ou réduction en % selon le type.
+type Enchantement struct {
+	Type   TypeEnchantement
+	Cible  string
+	Valeur int
+}
+
+var elements = []string{"feu", "glace", "foudre"}
+
+// Types de statut reconnus par le combat (voir StatusEffect)
+var statutsEnchantables = []string{"stun", "poison", "burn", "bleed"}
+
+// Types de monstres du donjon
+var typesMonstres = []string{"Bête", "Adepte", "Guerrier", "Vétéran", "Boss"}
+
+// Nombre maximal d'emplacements d'enchantement selon la rareté
+func MaxEnchantements(r Rarete) int {
+	switch r {
+	case Rare:
+		return 2
+	case Epique:
+		return 3
+	case Legendaire:
+		return 4
+	default:
+		return 1
+	}
+}
+
+// TirerEnchantement tire un enchantement adapté au type d'objet
+func TirerEnchantement(armure bool) Enchantement {
+	if armure {
+		return Enchantement{Type: EnchantProtection, Cible: typesMonstres[rand.Intn(len(typesMonstres))], Valeur: 10 + rand.Intn(16)}
+	}
+	if rand.Intn(2) == 0 {
+		return Enchantement{Type: EnchantElementaire, Cible: elements[rand.Intn(len(elements))], Valeur: 3 + rand.Intn(6)}
+	}
+	statut := statutsEnchantables[rand.Intn(len(statutsEnchantables))]
+	chance := 15 + rand.Intn(16)
+	if statut == "stun" {
+		chance = 8 + rand.Intn(8)
+	}
+	return Enchantement{Type: EnchantStatut, Cible: statut, Valeur: chance}
+}
+
+func libelleStatut(s string) string {
+	switch s {
+	case "stun":
+		return "étourdissement"
+	case "poison":
+		return "poison"
+	case "burn":
+		return "brûlure"
+	case "bleed":
+		return "saignement"
+	}
+	return s
+}
+
+func (e Enchantement) String() string {
+	switch e.Type {
+	case EnchantElementaire:
+		return fmt.Sprintf("%s +%d", e.Cible, e.Valeur)
+	case EnchantStatut:
+		return fmt.Sprintf("%d%% %s", e.Valeur, libelleStatut(e.Cible))
+	case EnchantProtection:
+		return fmt.Sprintf("-%d%% dégâts %s", e.Valeur, e.Cible)
+	}
+	return string(e.Type)
+}
@@ -0,0 +1,257 @@
+package objet
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Rareté d'un équipement trouvé ou fabriqué
+type Rarete string
+
+const (
+	Commun     Rarete = "commun"
+	Rare       Rarete = "rare"
+	Epique     Rarete = "épique"
+	Legendaire Rarete = "légendaire"
+)
+
+// Ordre croissant des raretés
+var OrdreRaretes = []Rarete{Commun, Rare, Epique, Legendaire}
+
+// Type de bonus aléatoire porté par un équipement
+type TypeAffixe string
+
+const (
+	AffixeForce      TypeAffixe = "force"
+	AffixeCritique   TypeAffixe = "critique"
+	AffixeVolDeVie   TypeAffixe = "vol de vie"
+	AffixeResistance TypeAffixe = "résistance"
+)
+
+type Affixe struct {
+	Type   TypeAffixe
+	Valeur int
+}
+
+// Exemplaire unique d'une arme ou d'une armure (rareté, affixes...).
+// Cle est la clé de CreerArme / CreerArmure, ID le distingue des autres exemplaires.
+type Exemplaire struct {
+	Cle     string
+	Armure  bool
+	ID      int
+	Rarete  Rarete
+	Affixes []Affixe
+	Niveau  int // niveau d'amélioration (+1 à +10)
+	// Enchantements posés à la forge (limités par MaxEnchantements)
+	Enchantements []Enchantement
+	// Qualité de fabrication (vide = normale, pour le butin)
+	Qualite Qualite `json:",omitempty"`
+}
+
+// NomBase retourne le nom affiché de l'objet de base
+func (e Exemplaire) NomBase() string {
+	if e.Armure {
+		return CreerArmure(e.Cle).Nom
+	}
+	return CreerArme(e.Cle).Nom
+}
+
+// Nom retourne le nom unique de l'exemplaire tel qu'il apparaît dans l'inventaire
+func (e Exemplaire) Nom() string {
+	base := e.NomBase()
+	if e.Niveau > 0 {
+		base += fmt.Sprintf(" +%d", e.Niveau)
+	}
+	etiquette := LibelleRarete(e.Rarete)
+	if e.Qualite != "" && e.Qualite != Normale {
+		etiquette = LibelleQualite(e.Qualite)
+		if e.Rarete != Commun {
+			etiquette += " " + LibelleRarete(e.Rarete)
+		}
+	}
+	return fmt.Sprintf("%s [%s #%d]", base, etiquette, e.ID)
+}
+
+// NomDeBase retire les décorations d'exemplaire (" +3 [Rare #3]") d'un nom d'inventaire
+func NomDeBase(nom string) string {
+	i := strings.Index(nom, " [")
+	if i < 0 {
+		return nom
+	}
+	nom = nom[:i]
+	if j := strings.LastIndex(nom, " +"); j >= 0 {
+		if _, err := strconv.Atoi(nom[j+2:]); err == nil {
+			nom = nom[:j]
+		}
+	}
+	return nom
+}
+
+// Niveau maximal d'amélioration à la forge
+const NiveauMaxAmelioration = 10
+
+// BonusAmelioration: +10% de la valeur de base par niveau, au moins +2 par niveau
+func BonusAmelioration(base, niveau int) int {
+	bonus := base * niveau / 10
+	if bonus < 2*niveau {
+		bonus = 2 * niveau
+	}
+	return bonus
+}
+
+// StatsArme retourne l'arme de base avec les bonus de l'exemplaire appliqués
+func (e Exemplaire) StatsArme() Arme {
+	a := CreerArme(e.Cle)
+	a.EffetAttaque += BonusAmelioration(a.EffetAttaque, e.Niveau) + BonusQualite(a.EffetAttaque, e.Qualite)
+	return a
+}
+
+// StatsArmure retourne l'armure de base avec les bonus de l'exemplaire appliqués
+func (e Exemplaire) StatsArmure() Armure {
+	a := CreerArmure(e.Cle)
+	a.EffetDefense += BonusAmelioration(a.EffetDefense, e.Niveau) + BonusQualite(a.EffetDefense, e.Qualite)
+	return a
+}
+
+// Bonus cumulé d'un type d'affixe
+func (e Exemplaire) Bonus(t TypeAffixe) int {
+	total := 0
+	for _, a := range e.Affixes {
+		if a.Type == t {
+			total += a.Valeur
+		}
+	}
+	return total
+}
+
+func LibelleRarete(r Rarete) string {
+	switch r {
+	case Rare:
+		return "Rare"
+	case Epique:
+		return "Épique"
+	case Legendaire:
+		return "Légendaire"
+	default:
+		return "Commun"
+	}
+}
+
+// Couleur ANSI associée à une rareté
+func CouleurRarete(r Rarete) string {
+	switch r {
+	case Rare:
+		return "\033[34m" // bleu
+	case Epique:
+		return "\033[35m" // violet
+	case Legendaire:
+		return "\033[33m" // or
+	default:
+		return ""
+	}
+}
+
+// Colorer entoure un texte de la couleur de la rareté
+func Colorer(r Rarete, s string) string {
+	c := CouleurRarete(r)
+	if c == "" {
+		return s
+	}
+	return c + s + "\033[0m"
+}
+
+// Multiplicateur de prix de vente selon la rareté
+func MultiplicateurVente(r Rarete) int {
+	switch r {
+	case Rare:
+		return 3
+	case Epique:
+		return 6
+	case Legendaire:
+		return 12
+	default:
+		return 1
+	}
+}
+
+// Nombre d'affixes tirés selon la rareté
+func NombreAffixes(r Rarete) int {
+	switch r {
+	case Rare:
+		return 1
+	case Epique:
+		return 2
+	case Legendaire:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// TirerRarete tire une rareté, les tiers élevés favorisant les objets rares
+func TirerRarete(tier int) Rarete {
+	// Poids: commun, rare, épique, légendaire
+	poids := map[int][4]int{
+		1: {80, 17, 3, 0},
+		2: {70, 23, 6, 1},
+		3: {60, 28, 10, 2},
+		4: {50, 32, 14, 4},
+	}
+	if tier < 1 {
+		tier = 1
+	}
+	if tier > 4 {
+		tier = 4
+	}
+	p := poids[tier]
+	roll := rand.Intn(100)
+	for i, w := range p {
+		if roll < w {
+			return OrdreRaretes[i]
+		}
+		roll -= w
+	}
+	return Commun
+}
+
+// TirerAffixes tire des affixes distincts dont la valeur dépend du tier
+func TirerAffixes(r Rarete, tier int) []Affixe {
+	types := []TypeAffixe{AffixeForce, AffixeCritique, AffixeVolDeVie, AffixeResistance}
+	rand.Shuffle(len(types), func(i, j int) { types[i], types[j] = types[j], types[i] })
+	n := NombreAffixes(r)
+	affixes := make([]Affixe, 0, n)
+	for _, t := range types[:n] {
+		affixes = append(affixes, Affixe{Type: t, Valeur: valeurAffixe(t, tier)})
+	}
+	return affixes
+}
+
+func valeurAffixe(t TypeAffixe, tier int) int {
+	switch t {
+	case AffixeForce:
+		return tier + rand.Intn(tier+1)
+	case AffixeCritique:
+		return 2*tier + rand.Intn(3)
+	case AffixeVolDeVie:
+		return tier + 1 + rand.Intn(2)
+	case AffixeResistance:
+		return 10*tier + rand.Intn(10)
+	}
+	return 0
+}
+
+func (a Affixe) String() string {
+	switch a.Type {
+	case AffixeForce:
+		return fmt.Sprintf("+%d Force", a.Valeur)
+	case AffixeCritique:
+		return fmt.Sprintf("+%d%% critique", a.Valeur)
+	case AffixeVolDeVie:
+		return fmt.Sprintf("%d%% vol de vie", a.Valeur)
+	case AffixeResistance:
+		return fmt.Sprintf("+%d%% résistance aux statuts", a.Valeur)
+	}
+	return string(a.Type)
+}
@@ -0,0 +1,97 @@
+package objet
+
+import "math/rand"
+
+// Qualité d'un équipement fabriqué à la forge
+type Qualite string
+
+const (
+	Mediocre    Qualite = "médiocre"
+	Normale     Qualite = "normale"
+	Superieure  Qualite = "supérieure"
+	ChefDOeuvre Qualite = "chef-d'œuvre"
+)
+
+// Ordre croissant des qualités
+var OrdreQualites = []Qualite{Mediocre, Normale, Superieure, ChefDOeuvre}
+
+// Niveau de compétence de forge à partir duquel les chances n'évoluent plus
+const CompetenceForgeMax = 10
+
+func LibelleQualite(q Qualite) string {
+	switch q {
+	case Mediocre:
+		return "Médiocre"
+	case Superieure:
+		return "Supérieur"
+	case ChefDOeuvre:
+		return "Chef-d'œuvre"
+	default:
+		return "Normal"
+	}
+}
+
+// PourcentageQualite: modificateur des statistiques de base (en %)
+func PourcentageQualite(q Qualite) int {
+	switch q {
+	case Mediocre:
+		return -15
+	case Superieure:
+		return 15
+	case ChefDOeuvre:
+		return 30
+	default:
+		return 0
+	}
+}
+
+// BonusQualite applique le modificateur de qualité à une valeur de base (au moins ±1)
+func BonusQualite(base int, q Qualite) int {
+	pct := PourcentageQualite(q)
+	bonus := base * pct / 100
+	if bonus == 0 && pct > 0 {
+		bonus = 1
+	}
+	if bonus == 0 && pct < 0 && base > 1 {
+		bonus = -1
+	}
+	return bonus
+}
+
+// TirerQualite tire la qualité d'un objet forgé; la compétence réduit les ratés
+// et augmente les chances d'objets supérieurs. Le résultat n'est jamais sous min.
+func TirerQualite(competence int, min Qualite) Qualite {
+	if competence > CompetenceForgeMax {
+		competence = CompetenceForgeMax
+	}
+	mediocre := 25 - 2*competence
+	superieure := 17 + competence + competence/2
+	chef := 3 + competence/2
+	// Poids: médiocre, normale, supérieure, chef-d'œuvre
+	poids := [4]int{mediocre, 100 - mediocre - superieure - chef, superieure, chef}
+	q := Normale
+	roll := rand.Intn(100)
+	for i, w := range poids {
+		if roll < w {
+			q = OrdreQualites[i]
+			break
+		}
+		roll -= w
+	}
+	if rangQualite(q) < rangQualite(min) {
+		return min
+	}
+	return q
+}
+
+func rangQualite(q Qualite) int {
+	if q == "" {
+		q = Normale
+	}
+	for i, o := range OrdreQualites {
+		if o == q {
+			return i
+		}
+	}
+	return 1
+}
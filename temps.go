@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// Heure à laquelle commence une nouvelle partie (premier jour)
+const heureDepart = 8
+
+// Heures de jeu écoulées pendant une salle du donjon
+const heuresParExpedition = 4
+
+// Heures de jeu passées à l'établi pour une fabrication (forge, alchimie, raffinage...)
+const heuresParFabrication = 1
+
+// Calendrier de Sloteria: semaines de 7 jours, mois de 30 jours, années de 12 mois
+const (
+	joursParMois = 30
+	moisParAn    = 12
+)
+
+var joursSemaine = []string{"Lundi", "Mardi", "Mercredi", "Jeudi", "Vendredi", "Samedi", "Dimanche"}
+
+var nomsMois = []string{"Janvier", "Février", "Mars", "Avril", "Mai", "Juin", "Juillet", "Août", "Septembre", "Octobre", "Novembre", "Décembre"}
+
+// Heures de la nuit: de debutNuit (inclus) à finNuit (exclu)
+const (
+	debutNuit = 21
+	finNuit   = 6
+)
+
+// Effets de la nuit au donjon
+const (
+	chanceCreatureNocturne = 35   // % de chance qu'une créature de la nuit remplace le monstre
+	bonusNuitMonstres      = 15   // % d'attaque et de PV en plus pour tous les monstres
+	bonusCreatureNocturne  = 10   // % supplémentaire pour les créatures de la nuit
+	transformationNuit     = 1.75 // multiplicateur du loup-garou transformé, au lieu de 1.5 le jour
+)
+
+// Créatures qui ne rôdent que la nuit, par tier de donjon (statistiques déjà ajustées au tier).
+// Leurs attaques spéciales sont dans monsterSpecialAttacks.
+var creaturesNocturnes = map[int]Monster{
+	1: {Nom: "Chauve-souris vampire", Type: "Bête", PV: 60, Attaque: 11, Defense: 1},
+	2: {Nom: "Goule", Type: "Bête", PV: 140, Attaque: 17, Defense: 5},
+	3: {Nom: "Spectre", Type: "Adepte", PV: 240, Attaque: 55, Defense: 18},
+	4: {Nom: "Loup-garou sauvage", Type: "Bête", PV: 420, Attaque: 90, Defense: 35},
+	5: {Nom: "Seigneur vampire", Type: "Vétéran", PV: 800, Attaque: 140, Defense: 60},
+}
+
+// avancerTemps fait avancer l'horloge du jeu; les marchands se réapprovisionnent à échéance
+func avancerTemps(gs *GameState, heures int) {
+	nuitAvant := estNuit(gs)
+	gs.Heures += heures
+	avancerStock(gs, heures)
+	if heures > 0 && estNuit(gs) != nuitAvant {
+		if estNuit(gs) {
+			fmt.Println("🌙 La nuit tombe sur Sloteria.")
+		} else {
+			fmt.Println("☀️ Le jour se lève sur Sloteria.")
+		}
+	}
+}
+
+// heureDuJour retourne l'heure courante (0-23)
+func heureDuJour(gs *GameState) int {
+	return gs.Heures % 24
+}
+
+func estNuit(gs *GameState) bool {
+	h := heureDuJour(gs)
+	return h >= debutNuit || h < finNuit
+}
+
+// periodeDuJour nomme le moment de la journée avec son icône
+func periodeDuJour(gs *GameState) string {
+	h := heureDuJour(gs)
+	switch {
+	case estNuit(gs):
+		return "🌙 Nuit"
+	case h < 8:
+		return "🌅 Aube"
+	case h >= 19:
+		return "🌇 Crépuscule"
+	default:
+		return "☀️ Jour"
+	}
+}
+
+// libelleHeure affiche la date et l'heure de jeu: « Lundi 3 Janvier, an 1 — 14h ☀️ Jour »
+func libelleHeure(gs *GameState) string {
+	return dateDuJeu(gs.Heures) + " " + periodeDuJour(gs)
+}
+
+// dateDuJeu formate un nombre d'heures de jeu: « Lundi 3 Janvier, an 1 — 14h »
+func dateDuJeu(heures int) string {
+	jour := heures / 24
+	mois := jour / joursParMois
+	return fmt.Sprintf("%s %d %s, an %d — %02dh", joursSemaine[jour%len(joursSemaine)], jour%joursParMois+1,
+		nomsMois[mois%moisParAn], mois/moisParAn+1, heures%24)
+}
+
+// heuresJusqua retourne le nombre d'heures avant l'heure h (0 si c'est maintenant)
+func heuresJusqua(gs *GameState, h int) int {
+	return (h - heureDuJour(gs) + 24) % 24
+}
+
+// appliquerNuit renforce le monstre la nuit et peut le remplacer par une créature nocturne
+func appliquerNuit(gs *GameState, mon *Monster, tier int) {
+	if !estNuit(gs) {
+		return
+	}
+	bonus := bonusNuitMonstres
+	if creature, ok := creaturesNocturnes[min(tier, 5)]; ok && rand.Intn(100) < chanceCreatureNocturne {
+		*mon = creature
+		bonus += bonusCreatureNocturne
+	}
+	mon.Attaque += mon.Attaque * bonus / 100
+	mon.PV += mon.PV * bonus / 100
+	mon.PVMax = mon.PV
+}
@@ -68,3 +68,157 @@ func selectWithArrows(header string, options []string) (int, bool) {
 		}
 	}
 }
+
+// selectMultipleWithArrows permet de cocher plusieurs options (Espace), Entrée valide, ESC/Q annule
+func selectMultipleWithArrows(header string, options []string) ([]int, bool) {
+	if err := keyboard.Open(); err != nil {
+		return nil, true
+	}
+	defer keyboard.Close()
+
+	index := 0
+	coches := make([]bool, len(options))
+	for {
+		clearHome()
+		clearScreenAll()
+		fmt.Println()
+		if header != "" {
+			fmt.Println(header)
+			fmt.Println()
+		}
+		for i, opt := range options {
+			prefix := "  "
+			if i == index {
+				prefix = "> "
+			}
+			box := "[ ]"
+			if coches[i] {
+				box = "[x]"
+			}
+			fmt.Printf("%s%s %s\n", prefix, box, opt)
+		}
+		fmt.Println()
+		fmt.Println("                    Contrôles: ↑/↓ naviguer | Espace cocher | A tout cocher | Entrée valider | Q retour")
+		fmt.Println()
+		ch, key, err := keyboard.GetKey()
+		if err != nil {
+			return nil, true
+		}
+		switch key {
+		case keyboard.KeyArrowUp:
+			if index > 0 {
+				index--
+			} else {
+				index = len(options) - 1
+			}
+		case keyboard.KeyArrowDown:
+			if index < len(options)-1 {
+				index++
+			} else {
+				index = 0
+			}
+		case keyboard.KeySpace:
+			coches[index] = !coches[index]
+		case keyboard.KeyEnter:
+			choix := []int{}
+			for i, c := range coches {
+				if c {
+					choix = append(choix, i)
+				}
+			}
+			return choix, false
+		case keyboard.KeyEsc:
+			return nil, true
+		}
+		switch ch {
+		case 'q', 'Q':
+			return nil, true
+		case 'a', 'A':
+			tout := true
+			for _, c := range coches {
+				tout = tout && c
+			}
+			for i := range coches {
+				coches[i] = !tout
+			}
+		}
+	}
+}
+
+// saisirQuantite demande un nombre entre 1 et maximum: chiffres au clavier, ←/→ ±1, ↑/↓ ±10,
+// M pour le maximum, Entrée valide, ESC/Q annule sans rien choisir
+func saisirQuantite(header string, maximum int) (int, bool) {
+	if maximum < 1 {
+		return 0, true
+	}
+	if err := keyboard.Open(); err != nil {
+		return 0, true
+	}
+	defer keyboard.Close()
+
+	valeur := 1
+	saisie := false // un chiffre tapé après une flèche recommence la saisie
+	for {
+		clearHome()
+		clearScreenAll()
+		fmt.Println()
+		if header != "" {
+			fmt.Println(header)
+			fmt.Println()
+		}
+		fmt.Printf("  Quantité: ◀ %d ▶   (1 – %d)\n", valeur, maximum)
+		fmt.Println()
+		fmt.Println("                    Contrôles: chiffres saisir | ←/→ ±1 | ↑/↓ ±10 | M maximum | Entrée valider | Q annuler")
+		fmt.Println()
+		ch, key, err := keyboard.GetKey()
+		if err != nil {
+			return 0, true
+		}
+		switch key {
+		case keyboard.KeyArrowLeft:
+			valeur--
+			saisie = false
+		case keyboard.KeyArrowRight:
+			valeur++
+			saisie = false
+		case keyboard.KeyArrowDown:
+			valeur -= 10
+			saisie = false
+		case keyboard.KeyArrowUp:
+			valeur += 10
+			saisie = false
+		case keyboard.KeyBackspace, keyboard.KeyBackspace2:
+			valeur /= 10
+			saisie = true
+		case keyboard.KeyEnter:
+			if valeur >= 1 {
+				return valeur, false
+			}
+		case keyboard.KeyEsc:
+			return 0, true
+		}
+		switch {
+		case ch >= '0' && ch <= '9':
+			if !saisie {
+				valeur = 0
+				saisie = true
+			}
+			valeur = valeur*10 + int(ch-'0')
+		case ch == 'm' || ch == 'M':
+			valeur = maximum
+			saisie = false
+		case ch == 'q' || ch == 'Q':
+			return 0, true
+		}
+		// La saisie en cours peut passer par 0 (effacement), jamais au-delà des bornes
+		if valeur > maximum {
+			valeur = maximum
+		}
+		if valeur < 1 && !saisie {
+			valeur = 1
+		}
+		if valeur < 0 {
+			valeur = 0
+		}
+	}
+}